@@ -0,0 +1,131 @@
+package main
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// writeHTML writes the report as a self-contained HTML page.
+// Source locations are linked using the URL template, see [sourceURL].
+func writeHTML(w io.Writer, r *report, urlTemplate string) error {
+	funcs := template.FuncMap{
+		"seconds": seconds,
+	}
+
+	tpl, err := template.New("report").Funcs(funcs).Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tpl.Execute(w, htmlReport(r, urlTemplate))
+}
+
+// sourceURL returns the URL of the source location.
+// The `{file}` and `{line}` placeholders in the template are replaced with the file path and the line number,
+// each segment of the file path is escaped.
+// If the template is empty, absolute paths are linked as `file://` URLs and relative paths are not linked.
+func sourceURL(urlTemplate string, l location) string {
+	if urlTemplate == "" {
+		if !filepath.IsAbs(l.file) {
+			return ""
+		}
+
+		urlTemplate = "file://{file}"
+	}
+
+	segments := strings.Split(filepath.ToSlash(l.file), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.NewReplacer("{file}", strings.Join(segments, "/"), "{line}", strconv.Itoa(l.line)).Replace(urlTemplate)
+}
+
+// ---
+
+type htmlData struct {
+	Tests    int
+	Failures int
+	Skipped  int
+	Packages []htmlPackage
+}
+
+type htmlPackage struct {
+	Name    string
+	Status  string
+	Elapsed float64
+	Output  string
+	Tests   []htmlTest
+}
+
+type htmlTest struct {
+	Name     string
+	Status   string
+	Elapsed  float64
+	Output   string
+	Failures []htmlFailure
+	Tags     []htmlLocation
+}
+
+type htmlFailure struct {
+	Location htmlLocation
+	Matcher  string
+	Subject  string
+	Actual   string
+	Expected string
+	Details  string
+}
+
+type htmlLocation struct {
+	Text string
+	URL  template.URL
+}
+
+func htmlReport(r *report, urlTemplate string) htmlData {
+	var data htmlData
+
+	link := func(l location) htmlLocation {
+		//nolint:gosec // the URL is built from the template specified by the user and paths from the test output
+		return htmlLocation{l.String(), template.URL(sourceURL(urlTemplate, l))}
+	}
+
+	for _, p := range r.packages {
+		hp := htmlPackage{p.name, p.status, p.elapsed, strings.Join(p.output, ""), nil}
+
+		for _, t := range p.tests {
+			ht := htmlTest{Name: t.name, Status: t.status, Elapsed: t.elapsed, Output: strings.Join(t.output, "")}
+
+			for _, f := range t.failures {
+				ht.Failures = append(ht.Failures, htmlFailure{link(f.location), f.matcher, f.subject, f.actual, f.expected, f.details})
+			}
+
+			for _, tag := range t.tags {
+				ht.Tags = append(ht.Tags, link(tag))
+			}
+
+			hp.Tests = append(hp.Tests, ht)
+			data.Tests++
+
+			switch t.status {
+			case actionFail:
+				data.Failures++
+			case actionSkip:
+				data.Skipped++
+			}
+		}
+
+		data.Packages = append(data.Packages, hp)
+	}
+
+	return data
+}
+
+// ---
+
+//go:embed report.html
+var htmlTemplate string
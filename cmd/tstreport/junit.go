@@ -0,0 +1,199 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeJUnit writes the report in JUnit XML format.
+func writeJUnit(w io.Writer, r *report) error {
+	suites := junitSuites{}
+
+	for _, p := range r.packages {
+		suite := junitSuite{
+			Name: p.name,
+			Time: seconds(p.elapsed),
+		}
+
+		for _, t := range p.tests {
+			tc := junitCase{
+				Name:      t.name,
+				ClassName: p.name,
+				Time:      seconds(t.elapsed),
+			}
+
+			switch t.status {
+			case actionFail:
+				tc.Failure = &junitFailure{
+					Message: t.summary(),
+					Type:    "failure",
+					Text:    t.failureText(),
+				}
+				suite.Failures++
+			case actionSkip:
+				tc.Skipped = &junitSkipped{Message: strings.Join(t.output, "")}
+				suite.Skipped++
+			}
+
+			suite.Tests++
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		if p.status == actionFail && suite.Failures == 0 {
+			suite.Errors++
+			suite.Tests++
+			suite.Cases = append(suite.Cases, junitCase{
+				Name:      packageCaseName,
+				ClassName: p.name,
+				Error: &junitFailure{
+					Message: "package failed",
+					Type:    "error",
+					Text:    strings.Join(p.output, ""),
+				},
+			})
+		}
+
+		if len(p.output) != 0 {
+			suite.SystemOut = &junitText{strings.Join(p.output, "")}
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+
+	err = encoder.Encode(suites)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
+
+// ---
+
+// summary returns a one-line summary of the first failure of the test.
+func (t *testCase) summary() string {
+	if len(t.failures) == 0 {
+		return "test failed"
+	}
+
+	f := t.failures[0]
+
+	text := "Expected"
+	if f.subject != "" {
+		text += " " + f.subject
+	}
+
+	text += " to " + strings.SplitN(f.expected, "\n", 2)[0]
+
+	if f.location.file != "" {
+		text += " at " + f.location.String()
+	}
+
+	return text
+}
+
+// failureText returns a plain text representation of the failures of the test,
+// or its raw output if no go-tst failures were recognized.
+func (t *testCase) failureText() string {
+	if len(t.failures) == 0 {
+		return strings.Join(t.output, "")
+	}
+
+	var sb strings.Builder
+
+	for _, f := range t.failures {
+		fmt.Fprintf(&sb, "%s\n", f.location)
+		if f.subject != "" {
+			fmt.Fprintf(&sb, "Expected %s\n", f.subject)
+		} else {
+			fmt.Fprintf(&sb, "Expected\n")
+		}
+
+		fmt.Fprintf(&sb, "%s\nto %s\n", indent(f.actual), f.expected)
+
+		if f.details != "" {
+			fmt.Fprintf(&sb, "%s\n", f.details)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	for _, tag := range t.tags {
+		fmt.Fprintf(&sb, "See %s\n", tag)
+	}
+
+	return sb.String()
+}
+
+func indent(text string) string {
+	return indentation + strings.ReplaceAll(text, "\n", "\n"+indentation)
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
+
+// ---
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Cases     []junitCase `xml:"testcase"`
+	SystemOut *junitText  `xml:"system-out,omitempty"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+type junitText struct {
+	Text string `xml:",cdata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// packageCaseName is the name of a test case reporting a package failure not attributed to any test,
+// like a build error or a panic in TestMain.
+const packageCaseName = "[package]"
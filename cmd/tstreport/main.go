@@ -0,0 +1,90 @@
+// Command tstreport converts the output of `go test -json` into JUnit XML and HTML reports.
+//
+// It recognizes failure messages, failure events and line tags logged by go-tst
+// and renders them as structured failure sections with links to the source code.
+//
+// Usage:
+//
+//	go test -json ./... | tstreport -junit report.xml -html report.html
+//
+// Flags:
+//
+//	-junit path
+//		write JUnit XML report to the file
+//	-html path
+//		write HTML report to the file
+//	-source template
+//		URL template for source links in the HTML report, like `https://github.com/org/repo/blob/main/{file}#L{line}`,
+//		absolute paths are linked as `file://` URLs by default
+//
+// Use `go test -fullpath` or enable [tst.Events] to get full source paths in the reports.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	junit := flag.String("junit", "", "write JUnit XML report to the file")
+	html := flag.String("html", "", "write HTML report to the file")
+	source := flag.String("source", "", "URL template for source links in the HTML report with {file} and {line} placeholders")
+
+	flag.Parse()
+
+	err := run(os.Stdin, *junit, *html, *source)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tstreport:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input io.Reader, junit, html, source string) error {
+	if junit == "" && html == "" {
+		return errors.New("no output specified, use -junit or -html flags")
+	}
+
+	r, err := readReport(input)
+	if err != nil {
+		return err
+	}
+
+	if junit != "" {
+		err = writeFile(junit, func(w io.Writer) error {
+			return writeJUnit(w, r)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if html != "" {
+		err = writeFile(html, func(w io.Writer) error {
+			return writeHTML(w, r, source)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	err = write(f)
+	if err != nil {
+		_ = f.Close()
+
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	r, err := readReport(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.packages) != 2 || len(r.packages[0].tests) != 2 {
+		t.Fatalf("Expected 2 packages with 2 tests in the first one, got %d packages", len(r.packages))
+	}
+
+	test := r.packages[0].tests[1]
+	if test.name != "TestFail" || test.status != actionFail || len(test.failures) != 2 || len(test.tags) != 1 {
+		t.Fatalf("Unexpected test %+v", test)
+	}
+
+	first := test.failures[0]
+	if first.location.String() != "ex_test.go:15" || first.subject != "" || first.actual != "<ex.S>: ex.S{A: 1, B: 2}" ||
+		first.expected != "equal to\n    <ex.S>: ex.S{A: 1, B: 3}" || first.details != "Differences:\n    .B:" {
+		t.Fatalf("Unexpected failure parsed from message %+v", first)
+	}
+
+	second := test.failures[1]
+	if second.location.String() != "/src/example/ex_test.go:16" || second.matcher != "Equal" || second.subject != "value #2" {
+		t.Fatalf("Unexpected failure parsed from event %+v", second)
+	}
+
+	var junit bytes.Buffer
+
+	err = writeJUnit(&junit, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`<testsuites tests="3" failures="1" errors="1" skipped="0">`,
+		`<failure message="Expected to equal to at ex_test.go:15" type="failure">`,
+		`<testcase name="[package]" classname="example.com/broken">`,
+	} {
+		if !strings.Contains(junit.String(), expected) {
+			t.Fatalf("Expected JUnit report to contain %s, got:\n%s", expected, junit.String())
+		}
+	}
+
+	var html bytes.Buffer
+
+	err = writeHTML(&html, r, "https://example.com/{file}#L{line}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`<a href="https://example.com//src/example/ex_test.go#L16">/src/example/ex_test.go:16</a>`,
+		`<pre class="actual">&lt;int&gt;: 2</pre>`,
+		`See <a href="https://example.com/example/ex_test.go#L15">example/ex_test.go:15</a>`,
+	} {
+		if !strings.Contains(html.String(), expected) {
+			t.Fatalf("Expected HTML report to contain %s, got:\n%s", expected, html.String())
+		}
+	}
+}
+
+const input = `{"Action":"output","Package":"example.com/ex","Test":"TestPass","Output":"=== RUN   TestPass\n"}
+{"Action":"pass","Package":"example.com/ex","Test":"TestPass","Elapsed":0}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"=== RUN   TestFail\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"    ex_test.go:15: \n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"        Expected \n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"            \u001b[31m<ex.S>: ex.S{A: 1, B: 2}\u001b[0m\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"        to equal to\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"            <ex.S>: ex.S{A: 1, B: 3}\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"        Differences:\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"            .B:\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"    ex_test.go:16: \n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"        Expected value #2\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"            <int>: 2\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"        to equal to\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"            <int>: 4\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"    ex_test.go:16: tst:event {\"file\":\"/src/example/ex_test.go\",\"line\":16,\"matcher\":\"Equal\",\"subject\":\"value #2\",\"actual\":\"<int>: 2\",\"expected\":\"equal to\\n    <int>: 4\"}\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"    ex_test.go:14: See example/ex_test.go:15\n"}
+{"Action":"output","Package":"example.com/ex","Test":"TestFail","Output":"--- FAIL: TestFail (0.00s)\n"}
+{"Action":"fail","Package":"example.com/ex","Test":"TestFail","Elapsed":0}
+{"Action":"fail","Package":"example.com/ex","Elapsed":0.003}
+{"ImportPath":"example.com/broken","Action":"build-output","Output":"# example.com/broken\n"}
+{"Action":"output","Package":"example.com/broken","Output":"FAIL\texample.com/broken [build failed]\n"}
+{"Action":"fail","Package":"example.com/broken","Elapsed":0}
+`
+
+func TestParseFailure(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+		details  string
+	}{
+		{
+			"and",
+			"\nExpected \n    <int>: 1\nto be greater than\n    <int>: 5\nand be less than\n    <int>: 0\n✗ be greater than\n      <int>: 5",
+			"be greater than\n    <int>: 5\nand be less than\n    <int>: 0",
+			"✗ be greater than\n      <int>: 5",
+		},
+		{
+			"or",
+			"\nExpected \n    <int>: 1\nto equal to\n    <int>: 2\nor \n    be zero\n    and be nil\n✗ equal to\n      <int>: 2",
+			"equal to\n    <int>: 2\nor \n    be zero\n    and be nil",
+			"✗ equal to\n      <int>: 2",
+		},
+		{
+			"struct",
+			"\nExpected \n    <ex.S>: ex.S{ID: 1}\nto be a struct that is expected to\n" +
+				"1.     have field \"ID\" that is expected to equal to\n        <int>: 2\n\n✗ have field \"ID\" that is expected to equal to",
+			"be a struct that is expected to\n1.     have field \"ID\" that is expected to equal to\n        <int>: 2",
+			"✗ have field \"ID\" that is expected to equal to",
+		},
+		{
+			"regexp",
+			"\nExpected \n    <string>: [5] \"id=42\"\nto match regular expression\n    <string>: [8] \"id=(\\\\d+)\"\n" +
+				"with group \"id\" that is expected to equal to\n    <string>: [2] \"43\"\nCaptured groups:\n    id: <string>: [2] \"42\"",
+			"match regular expression\n    <string>: [8] \"id=(\\\\d+)\"\nwith group \"id\" that is expected to equal to\n    <string>: [2] \"43\"",
+			"Captured groups:\n    id: <string>: [2] \"42\"",
+		},
+		{
+			"match-all-elements",
+			"\nExpected \n    <[]int>: [1] []int{1}\nto match all elements identified by func(int) int\n" +
+				"with element 2 that is expected to be anything\nElement mismatches:\n    element with id 2 is missing",
+			"match all elements identified by func(int) int\nwith element 2 that is expected to be anything",
+			"Element mismatches:\n    element with id 2 is missing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, ok := parseFailure(tc.text)
+			if !ok {
+				t.Fatalf("Expected failure to be parsed from %q", tc.text)
+			}
+
+			if f.expected != tc.expected || f.details != tc.details {
+				t.Fatalf("Unexpected failure parsed:\nexpected: %q\ndetails: %q", f.expected, f.details)
+			}
+		})
+	}
+}
+
+func TestSourceURL(t *testing.T) {
+	actual := sourceURL("https://example.com/{file}#L{line}", location{"src/a b/c#d?.go", 7})
+	if actual != "https://example.com/src/a%20b/c%23d%3F.go#L7" {
+		t.Fatalf("Unexpected source URL %s", actual)
+	}
+
+	actual = sourceURL("", location{"/src/100%/x.go", 1})
+	if actual != "file:///src/100%25/x.go" {
+		t.Fatalf("Unexpected source URL %s", actual)
+	}
+}
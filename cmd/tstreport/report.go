@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+// event is a record produced by `go test -json`, see `go doc test2json`.
+type event struct {
+	Action  string
+	Package string
+	Test    string
+	Output  string
+	Elapsed float64
+}
+
+// ---
+
+// report holds results of all packages in the order they appear in the input.
+type report struct {
+	packages []*testPackage
+	index    map[string]*testPackage
+}
+
+// testPackage holds results of a single package.
+type testPackage struct {
+	name    string
+	status  string
+	elapsed float64
+	output  []string
+	tests   []*testCase
+	index   map[string]*testCase
+}
+
+// testCase holds results of a single test or sub-test.
+type testCase struct {
+	name     string
+	status   string
+	elapsed  float64
+	output   []string
+	failures []failure
+	tags     []location
+}
+
+// failure is a structured representation of a go-tst failure message or event.
+type failure struct {
+	location location
+	matcher  string
+	subject  string
+	actual   string
+	expected string
+	details  string
+}
+
+// location is a position in the source code.
+type location struct {
+	file string
+	line int
+}
+
+func (l location) String() string {
+	return fmt.Sprintf("%s:%d", l.file, l.line)
+}
+
+// ---
+
+// readReport reads `go test -json` output and collects the results.
+// Lines that are not JSON objects and events not related to a package, like build output, are ignored.
+func readReport(r io.Reader) (*report, error) {
+	result := &report{index: map[string]*testPackage{}}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLength)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var e event
+
+		err := json.Unmarshal(line, &e)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse event %q: %w", line, err)
+		}
+
+		result.add(e)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	for _, p := range result.packages {
+		for _, t := range p.tests {
+			t.parseOutput()
+		}
+	}
+
+	return result, nil
+}
+
+func (r *report) add(e event) {
+	if e.Package == "" {
+		return
+	}
+
+	p := r.index[e.Package]
+	if p == nil {
+		p = &testPackage{name: e.Package, index: map[string]*testCase{}}
+		r.index[e.Package] = p
+		r.packages = append(r.packages, p)
+	}
+
+	if e.Test == "" {
+		switch e.Action {
+		case actionOutput:
+			p.output = append(p.output, e.Output)
+		case actionPass, actionFail, actionSkip:
+			p.status = e.Action
+			p.elapsed = e.Elapsed
+		}
+
+		return
+	}
+
+	t := p.index[e.Test]
+	if t == nil {
+		t = &testCase{name: e.Test}
+		p.index[e.Test] = t
+		p.tests = append(p.tests, t)
+	}
+
+	switch e.Action {
+	case actionOutput:
+		t.output = append(t.output, e.Output)
+	case actionPass, actionFail, actionSkip:
+		t.status = e.Action
+		t.elapsed = e.Elapsed
+	}
+}
+
+// ---
+
+// parseOutput recognizes go-tst failure messages, failure events and line tags in the test output.
+func (t *testCase) parseOutput() {
+	for _, entry := range logEntries(t.output) {
+		switch {
+		case strings.HasPrefix(entry.text, tst.EventPrefix):
+			if e, ok := tst.ParseEvent(entry.text); ok {
+				t.addEvent(e)
+			}
+		case strings.HasPrefix(entry.text, "See "):
+			if tag, ok := parseLocation(strings.TrimPrefix(entry.text, "See ")); ok {
+				t.tags = append(t.tags, tag)
+			}
+		default:
+			if f, ok := parseFailure(entry.text); ok {
+				f.location = entry.location
+				t.failures = append(t.failures, f)
+			}
+		}
+	}
+}
+
+// addEvent adds a failure from the event or completes the failure parsed from the message logged right before it.
+func (t *testCase) addEvent(e tst.Event) {
+	f := failure{
+		location: location{e.File, e.Line},
+		matcher:  e.Matcher,
+		subject:  e.Subject,
+		actual:   e.Actual,
+		expected: e.Expected,
+		details:  e.Diff,
+	}
+
+	if n := len(t.failures); n != 0 {
+		last := t.failures[n-1]
+		if last.matcher == "" && last.location.line == e.Line && path.Base(last.location.file) == path.Base(e.File) {
+			t.failures[n-1] = f
+
+			return
+		}
+	}
+
+	t.failures = append(t.failures, f)
+}
+
+// ---
+
+// logEntry is a message logged by a test using t.Log or similar functions.
+type logEntry struct {
+	location location
+	text     string
+}
+
+// logEntries joins lines of the test output into log entries.
+// Each entry starts with an indented `file.go:line: ` header, and its continuation lines are indented 4 more spaces.
+// ANSI color sequences are removed.
+func logEntries(output []string) []logEntry {
+	var entries []logEntry
+
+	continuation := ""
+
+	for _, line := range output {
+		line = ansiSequence.ReplaceAllString(strings.TrimSuffix(line, "\n"), "")
+
+		if n := len(entries); n != 0 && continuation != "" && strings.HasPrefix(line, continuation) {
+			entries[n-1].text += "\n" + strings.TrimPrefix(line, continuation)
+
+			continue
+		}
+
+		continuation = ""
+
+		m := logEntryHeader.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		lineNumber, _ := strconv.Atoi(m[3])
+		entries = append(entries, logEntry{location{m[2], lineNumber}, m[4]})
+		continuation = m[1] + strings.Repeat(" ", 4)
+	}
+
+	return entries
+}
+
+// parseFailure parses a failure message in the form produced by go-tst:
+//
+//	Expected <subject>
+//	    <actual value>
+//	to <assertion description>
+//	<details>
+//
+// The assertion description continues with indented lines and with unindented lines of composite assertions,
+// like `and …` or `or …` of [tst.And] and [tst.Or], `1. …` of [tst.Struct], `with group …` of [tst.MatchRegexp]
+// and `with element …` of [tst.MatchAllElements]. An empty line ends the description.
+// Any other unindented line starts the details, like `Differences:`.
+func parseFailure(text string) (failure, bool) {
+	lines := strings.Split(strings.TrimPrefix(text, "\n"), "\n")
+
+	subject, ok := strings.CutPrefix(lines[0], "Expected")
+	if !ok || (subject != "" && subject[0] != ' ') {
+		return failure{}, false
+	}
+
+	f := failure{subject: strings.TrimSpace(subject)}
+
+	i := 1
+
+	var actual []string
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], "to "); i++ {
+		actual = append(actual, strings.TrimPrefix(lines[i], indentation))
+	}
+
+	if i == len(lines) {
+		return failure{}, false
+	}
+
+	expected := []string{strings.TrimPrefix(lines[i], "to ")}
+	for i++; i < len(lines) && (strings.HasPrefix(lines[i], " ") || descriptionContinuation.MatchString(lines[i])); i++ {
+		expected = append(expected, lines[i])
+	}
+
+	if i < len(lines) && lines[i] == "" {
+		i++
+	}
+
+	f.actual = strings.Join(actual, "\n")
+	f.expected = strings.Join(expected, "\n")
+	f.details = strings.Join(lines[i:], "\n")
+
+	return f, true
+}
+
+// parseLocation parses a `file:line` string.
+func parseLocation(text string) (location, bool) {
+	i := strings.LastIndexByte(text, ':')
+	if i <= 0 {
+		return location{}, false
+	}
+
+	line, err := strconv.Atoi(text[i+1:])
+	if err != nil {
+		return location{}, false
+	}
+
+	return location{text[:i], line}, true
+}
+
+// ---
+
+var (
+	logEntryHeader = regexp.MustCompile(`^( +)(\S.*?\.go):(\d+): ?(.*)$`)
+	ansiSequence   = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	descriptionContinuation = regexp.MustCompile(`^(and |or |with group |with element |\d+\. )`)
+)
+
+const (
+	actionOutput = "output"
+	actionPass   = "pass"
+	actionFail   = "fail"
+	actionSkip   = "skip"
+)
+
+const (
+	indentation   = "    "
+	maxLineLength = 16 << 20
+)
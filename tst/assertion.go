@@ -6,139 +6,172 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"runtime"
 	"strings"
 )
 
 // Not returns an assertion that passes in case the specified assertion do not pass and vise versa.
 func Not(assertion Assertion) Assertion {
-	return not{assertion}
+	return not{"Not", assertion}
 }
 
 // And returns an assertion that passes in case all of the specified assertions pass.
 func And(assertions ...Assertion) Assertion {
-	return and{assertions}
+	return and{"And", assertions}
 }
 
 // Or returns an assertion that passes in case any of the specified assertions pass.
 func Or(assertions ...Assertion) Assertion {
-	return or{assertions}
+	return or{"Or", assertions}
 }
 
 // Equal returns an assertion that passes in case values to be tested using it equal to the specified values.
 // Number of values to test with this assertion must match the number of the specified values.
+//
+// Values are compared deeply, and values having a method `Equal(T) bool`, like [time.Time],
+// are compared using that method, including values nested in structs, slices and maps,
+// and values of unexported struct fields unless they are only reachable through map values or interfaces.
+// Use [EqualWith] with [IgnoreEqualMethods] option to compare such values structurally.
 func Equal(values ...any) Assertion {
-	return equal{values}
+	return equal{"Equal", values, defaultEquality}
+}
+
+// EqualWith returns an assertion that passes in case values to be tested using it equal to the specified value
+// using the deep equality test customized with the specified options.
+func EqualWith(expected any, options ...EqualityOption) Assertion {
+	return equal{"EqualWith", []any{expected}, newEquality(options...)}
 }
 
 // EqualUsing returns an assertion that passes in case values to be tested are equal to the specified values
 // using the equality test function f.
+// The function f must have a signature like `func(A, E) bool` where the values to test are assignable to A
+// and the specified values are assignable to E.
+// Nil values are passed to f as zero values of the corresponding argument types if these types are nillable.
 func EqualUsing(f any, values ...any) Assertion {
-	return equalUsing{f, values}
+	return equalUsing{"EqualUsing", f, values}
+}
+
+// EqualBy returns an assertion that passes in case values to be tested are equal to the specified values
+// using the equality test function f.
+// It is a type-safe version of [EqualUsing].
+func EqualBy[A, E any](f func(A, E) bool, values ...E) Assertion {
+	return equalBy[A, E]{"EqualBy", f, values}
 }
 
 // NotEqual returns an assertion that passes in case values to be tested using it not equal to the specified values.
 // Number of values to test with this assertion must match the number of the specified values.
 func NotEqual(values ...any) Assertion {
-	return Not(Equal(values...))
+	return not{"NotEqual", Equal(values...)}
 }
 
 // BeLessThan returns an assertion that passes in case values to be tested using it are less than corresponding specified values.
 // Number of values to test with this assertion must match the number of the specified values.
+//
+// Values can be booleans, strings, numbers of any kind, or values of types having either `Compare(T) int` method
+// or `Before(T) bool` and `After(T) bool` methods, like [time.Time].
+// The same applies to all other comparison assertions.
 func BeLessThan(values ...any) Assertion {
-	return comparison{values, lt, "less than"}
+	return comparison{"BeLessThan", values, lt, "less than"}
 }
 
 // LessThan returns an assertion that passes in case values to be tested using it are less than corresponding specified values.
 func LessThan(values ...any) Assertion {
-	return BeLessThan(values...)
+	return comparison{"LessThan", values, lt, "less than"}
 }
 
 // BeLessOrEqualThan returns an assertion that passes in case values to be tested
 // using it are less or equal than corresponding specified values.
 func BeLessOrEqualThan(values ...any) Assertion {
-	return comparison{values, le, "less or equal than"}
+	return comparison{"BeLessOrEqualThan", values, le, "less or equal than"}
 }
 
 // LessOrEqualThan returns an assertion that passes in case values to be tested
 // using it are less or equal than corresponding specified values.
 func LessOrEqualThan(values ...any) Assertion {
-	return BeLessOrEqualThan(values...)
+	return comparison{"LessOrEqualThan", values, le, "less or equal than"}
 }
 
 // BeGreaterThan returns an assertion that passes in case values to be tested
 // using it are greater than corresponding specified values.
 func BeGreaterThan(values ...any) Assertion {
-	return comparison{values, gt, "greater than"}
+	return comparison{"BeGreaterThan", values, gt, "greater than"}
 }
 
 // GreaterThan returns an assertion that passes in case values to be tested
 // using it are greater than corresponding specified values.
 func GreaterThan(values ...any) Assertion {
-	return BeGreaterThan(values...)
+	return comparison{"GreaterThan", values, gt, "greater than"}
 }
 
 // BeGreaterOrEqualThan returns an assertion that passes in case values to be tested
 // using it are greater or equal than corresponding specified values.
 func BeGreaterOrEqualThan(values ...any) Assertion {
-	return comparison{values, ge, "greater or equal than"}
+	return comparison{"BeGreaterOrEqualThan", values, ge, "greater or equal than"}
 }
 
 // GreaterOrEqualThan returns an assertion that passes in case values to be tested
 // using it are greater or equal than corresponding specified values.
 func GreaterOrEqualThan(values ...any) Assertion {
-	return BeGreaterOrEqualThan(values...)
+	return comparison{"GreaterOrEqualThan", values, ge, "greater or equal than"}
 }
 
 // BeTrue returns an assertion that passes in case all the values to be tested
 // are boolean and equal to true.
 func BeTrue() Assertion {
-	return boolean{true}
+	return boolean{"BeTrue", true}
 }
 
 // BeFalse returns an assertion that passes in case all the values to be tested
 // are boolean and equal to false.
 func BeFalse() Assertion {
-	return boolean{false}
+	return boolean{"BeFalse", false}
 }
 
 // BeZero returns an assertion that passes in case all the values to be tested
 // are zero initialized.
 func BeZero() Assertion {
-	return zero{}
+	return zero{"BeZero"}
 }
 
 // BeNil returns an assertion that passes in case all the values to be tested
 // are nil.
 func BeNil() Assertion {
-	return nilValue{}
+	return nilValue{"BeNil"}
 }
 
 // HaveOccurred returns an assertion that passes in case all the values to be tested
 // are non-nil errors.
 func HaveOccurred() Assertion {
-	return Not(nilError{})
+	return not{"HaveOccurred", nilError{"HaveOccurred"}}
 }
 
 // MatchError returns an assertion that passes in case all the values to be tested
 // are errors that pass test `errors.Is(actual, expected)`.
 func MatchError(expected error) Assertion {
 	if expected == nil {
-		return nilError{}
+		return nilError{"MatchError"}
 	}
 
-	return matchError{expected}
+	return matchError{"MatchError", expected}
 }
 
 // HaveLen returns an assertion that passes in case all the values to be tested
 // have length equal to the specified value.
 func HaveLen(n ...any) Assertion {
-	return haveLen{n}
+	return haveLen{"HaveLen", n}
 }
 
 // HaveField returns an assertion that passes in case all the struct values to be tested
 // have a field with the specified name and the specified assertion passes for the field value.
+//
+// The name can be a path to a nested value consisting of field names separated by dots,
+// indexes of slice or array elements, map keys and calls of methods having no arguments,
+// like `Spec.Containers[0].Image`, `Labels["app"]` or `Status().Phase`.
+// Pointers and interfaces are dereferenced along the path.
+// The assertion fails if any step of the path cannot be resolved, for example, due to a nil pointer or a missing map key.
 func HaveField(name string, assertion Assertion) Assertion {
-	return haveField{name, assertion}
+	return haveField{"HaveField", name, assertion}
 }
 
 // Field returns an assertion that passes in case all the struct values to be tested
@@ -146,19 +179,64 @@ func HaveField(name string, assertion Assertion) Assertion {
 // It is an alias for HaveField and is provided for better readability
 // when used in [Struct] or [Contain] assertions.
 func Field(name string, assertion Assertion) Assertion {
-	return haveField{name, assertion}
+	return haveField{"Field", name, assertion}
 }
 
 // Contain returns an assertion that passes in case all the array or slice values to be tested
 // contain at least one element matching each of the given assertions.
+//
+// In case of failure, the elements that came closest to passing the assertion are reported,
+// that is the elements with the least number of differences or failed sub-assertions.
 func Contain(assertion Assertion) Assertion {
-	return contain{assertion}
+	return contain{"Contain", assertion, 1, atLeast}
+}
+
+// ContainN returns an assertion that passes in case all the array or slice values to be tested
+// contain exactly n elements matching the given assertion.
+func ContainN(n int, assertion Assertion) Assertion {
+	return contain{"ContainN", assertion, n, exactly}
+}
+
+// ContainAtLeast returns an assertion that passes in case all the array or slice values to be tested
+// contain n or more elements matching the given assertion.
+func ContainAtLeast(n int, assertion Assertion) Assertion {
+	return contain{"ContainAtLeast", assertion, n, atLeast}
+}
+
+// ContainAtMost returns an assertion that passes in case all the array or slice values to be tested
+// contain no more than n elements matching the given assertion.
+func ContainAtMost(n int, assertion Assertion) Assertion {
+	return contain{"ContainAtMost", assertion, n, atMost}
 }
 
 // Struct returns an assertion that passes in case all the values to be tested
 // are structs each of them containing at least one field matching any of the expected field assertions.
 func Struct(fields ...Assertion) Assertion {
-	return beStruct{fields}
+	return beStruct{"Struct", fields, false}
+}
+
+// StructStrict returns an assertion that passes in case all the values to be tested
+// are structs matching all the expected field assertions like in [Struct],
+// and each exported field of the struct is covered by at least one of the field assertions made using [Field] or [HaveField],
+// directly or nested in [Not], [And], [Or] and [Struct].
+// This way a newly added field does not go unnoticed.
+// Use [Ignore] to explicitly exclude a field from the check, like `Field("UpdatedAt", Ignore())`.
+func StructStrict(fields ...Assertion) Assertion {
+	return beStruct{"StructStrict", fields, true}
+}
+
+// MatchAllFields returns an assertion that passes in case all the values to be tested
+// are structs matching all the expected field assertions and having no exported fields not covered by them.
+// It is an alias for [StructStrict].
+func MatchAllFields(fields ...Assertion) Assertion {
+	return beStruct{"MatchAllFields", fields, true}
+}
+
+// Ignore returns an assertion that passes for any value.
+// It is useful to explicitly exclude a field from the check in [StructStrict]
+// or an element in [MatchAllElements].
+func Ignore() Assertion {
+	return anything{"Ignore"}
 }
 
 // ---
@@ -166,20 +244,36 @@ func Struct(fields ...Assertion) Assertion {
 // Assertion is an abstract assertion that can be composite and can be used to build expectations.
 type Assertion interface {
 	check(actual []any) ([]bool, error)
-	description() string
+	description(p *printer) string
 	complexity() int
 	at(int) Assertion
 }
 
+// matcher is embedded in each assertion to keep the name of its exported constructor, like `Equal` or `Contain`.
+type matcher string
+
+func (m matcher) matcherName() string {
+	return string(m)
+}
+
+// explainer is an optional interface that can be implemented by an [Assertion]
+// to provide additional details explaining why the value did not pass it.
+type explainer interface {
+	explain(p *printer, actual any) string
+}
+
 // ---
 
 type equal struct {
+	matcher
+
 	expected []any
+	equality *equality
 }
 
 func (a equal) check(actual []any) ([]bool, error) {
-	if len(a.expected) != len(actual) && len(a.expected) != 1 {
-		return nil, errNumberOfValuesToTestDiffersError{len(actual), len(a.expected)}
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
 	}
 
 	expected := func(i int) any {
@@ -192,14 +286,14 @@ func (a equal) check(actual []any) ([]bool, error) {
 
 	result := make([]bool, len(actual))
 	for i := range actual {
-		result[i] = reflect.DeepEqual(actual[i], expected(i))
+		result[i] = a.equality.equal(actual[i], expected(i))
 	}
 
 	return result, nil
 }
 
-func (a equal) description() string {
-	return "equal to\n" + indent(1, values(a.expected).description())
+func (a equal) description(p *printer) string {
+	return "equal to\n" + indent(1, values(a.expected).description(p))
 }
 
 func (a equal) complexity() int {
@@ -211,19 +305,53 @@ func (a equal) at(i int) Assertion {
 		return a
 	}
 
-	return equal{[]any{a.expected[i]}}
+	return equal{a.matcher, []any{a.expected[i]}, a.equality}
+}
+
+func (a equal) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.expected))
+}
+
+func (a equal) distance(actual any) int {
+	if len(a.expected) != 1 {
+		return 1
+	}
+
+	return len(a.equality.diff(actual, a.expected[0]))
+}
+
+func (a equal) explain(p *printer, actual any) string {
+	if len(a.expected) != 1 {
+		return ""
+	}
+
+	x, xok := bytesOf(reflect.ValueOf(actual))
+	y, yok := bytesOf(reflect.ValueOf(a.expected[0]))
+
+	if xok && yok && reflect.TypeOf(actual) == reflect.TypeOf(a.expected[0]) && max(len(x), len(y)) > p.hexDumpThreshold {
+		return p.hexDiff(x, y)
+	}
+
+	diff := a.equality.diff(actual, a.expected[0])
+	if len(diff) == 0 || len(diff) == 1 && diff[0].path == "" {
+		return ""
+	}
+
+	return differences(diff).description(p)
 }
 
 // ---
 
 type equalUsing struct {
+	matcher
+
 	f        any
 	expected []any
 }
 
 func (a equalUsing) check(actual []any) ([]bool, error) {
-	if len(a.expected) != len(actual) && len(a.expected) != 1 {
-		return nil, errNumberOfValuesToTestDiffersError{len(actual), len(a.expected)}
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
 	}
 
 	expected := func(i int) any {
@@ -239,30 +367,36 @@ func (a equalUsing) check(actual []any) ([]bool, error) {
 		return nil, errUnexpectedAssertionTypeError{0, typeOf(a.f), "function"}
 	}
 
-	if rf.Type().NumIn() != 2 || rf.Type().NumOut() != 1 {
+	ft := rf.Type()
+	if ft.NumIn() != 2 || ft.NumOut() != 1 || ft.IsVariadic() {
 		return nil, errUnexpectedAssertionTypeError{0, typeOf(a.f), "function with two arguments and one return value"}
 	}
 
-	if rf.Type().In(0) != reflect.TypeOf(actual[0]) || rf.Type().In(1) != reflect.TypeOf(expected(0)) {
-		return nil, errUnexpectedAssertionTypeError{0, typeOf(a.f), fmt.Sprintf("func(%s, %s) bool", typeOf(actual[0]), typeOf(expected(0)))}
-	}
-
-	if rf.Type().Out(0) != reflect.TypeOf(true) {
+	if ft.Out(0).Kind() != reflect.Bool {
 		return nil, errUnexpectedAssertionTypeError{0, typeOf(a.f), "function with return value of type bool"}
 	}
 
 	result := make([]bool, len(actual))
 
 	for i := range actual {
-		out := rf.Call([]reflect.Value{reflect.ValueOf(actual[i]), reflect.ValueOf(expected(i))})
-		result[i] = out[0].Bool()
+		x, err := argument(ft.In(0), actual[i], i, false)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := argument(ft.In(1), expected(i), i, true)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = rf.Call([]reflect.Value{x, y})[0].Bool()
 	}
 
 	return result, nil
 }
 
-func (a equalUsing) description() string {
-	return "equal to\n" + indent(1, values(a.expected).description())
+func (a equalUsing) description(p *printer) string {
+	return equalUsingDescription(p, reflect.ValueOf(a.f), a.expected)
 }
 
 func (a equalUsing) complexity() int {
@@ -274,20 +408,149 @@ func (a equalUsing) at(i int) Assertion {
 		return a
 	}
 
-	return equal{[]any{a.expected[i]}}
+	return equalUsing{a.matcher, a.f, []any{a.expected[i]}}
+}
+
+func (a equalUsing) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.expected))
+}
+
+// ---
+
+type equalBy[A, E any] struct {
+	matcher
+
+	f        func(A, E) bool
+	expected []E
+}
+
+func (a equalBy[A, E]) check(actual []any) ([]bool, error) {
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
+	}
+
+	expected := func(i int) E {
+		if len(a.expected) == 1 {
+			return a.expected[0]
+		}
+
+		return a.expected[i]
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		x, err := typedArgument[A](actual[i], i)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = a.f(x, expected(i))
+	}
+
+	return result, nil
+}
+
+func (a equalBy[A, E]) description(p *printer) string {
+	return equalUsingDescription(p, reflect.ValueOf(a.f), anySlice(a.expected))
+}
+
+func (a equalBy[A, E]) complexity() int {
+	return 1
+}
+
+func (a equalBy[A, E]) at(i int) Assertion {
+	if len(a.expected) == 1 {
+		return a
+	}
+
+	return equalBy[A, E]{a.matcher, a.f, []E{a.expected[i]}}
+}
+
+func (a equalBy[A, E]) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.expected))
+}
+
+// ---
+
+func equalUsingDescription(p *printer, f reflect.Value, expected []any) string {
+	return "equal using " + funcName(f) + " to\n" + indent(1, values(expected).description(p))
+}
+
+// funcName returns the qualified name of the function f or its signature if it is an anonymous function.
+func funcName(f reflect.Value) string {
+	if f.Kind() != reflect.Func || f.IsNil() {
+		return typeOf(valueOf(f))
+	}
+
+	fn := runtime.FuncForPC(f.Pointer())
+	if fn == nil || anonymousFunc.MatchString(fn.Name()) {
+		return f.Type().String()
+	}
+
+	name := fn.Name()
+	if i := strings.LastIndexByte(name, '/'); i != -1 {
+		name = name[i+1:]
+	}
+
+	return name
+}
+
+// argument converts the value v with index i to a value that can be passed
+// as an argument of type t to a function called using reflection.
+func argument(t reflect.Type, v any, i int, inAssertion bool) (reflect.Value, error) {
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			return reflect.Zero(t), nil
+		default:
+			return reflect.Value{}, errUnexpectedNilValueError{i, t.String(), inAssertion}
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(t) {
+		if inAssertion {
+			return reflect.Value{}, errUnexpectedAssertionTypeError{i, rv.Type().String(), t.String()}
+		}
+
+		return reflect.Value{}, errUnexpectedValueTypeError{i, rv.Type().String(), t.String()}
+	}
+
+	return rv, nil
+}
+
+// typedArgument converts the value v with index i to a value of type T.
+// Nil values are converted to zero values of T if T is nillable.
+func typedArgument[T any](v any, i int) (T, error) {
+	var zero T
+
+	rv, err := argument(reflect.TypeFor[T](), v, i, false)
+	if err != nil {
+		return zero, err
+	}
+
+	result, ok := rv.Interface().(T)
+	if !ok {
+		return zero, nil
+	}
+
+	return result, nil
 }
 
 // ---
 
 type comparison struct {
+	matcher
+
 	expected            []any
 	expectedResult      func(int) bool
 	expectedDescription string
 }
 
 func (a comparison) check(actual []any) ([]bool, error) {
-	if len(a.expected) != len(actual) && len(a.expected) != 1 {
-		return nil, errNumberOfValuesToTestDiffersError{len(actual), len(a.expected)}
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
 	}
 
 	expected := func(i int) any {
@@ -315,8 +578,8 @@ func (a comparison) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a comparison) description() string {
-	return "be " + a.expectedDescription + "\n" + indent(1, values(a.expected).description())
+func (a comparison) description(p *printer) string {
+	return "be " + a.expectedDescription + "\n" + indent(1, values(a.expected).description(p))
 }
 
 func (a comparison) complexity() int {
@@ -328,12 +591,18 @@ func (a comparison) at(i int) Assertion {
 		return a
 	}
 
-	return comparison{[]any{a.expected[i]}, a.expectedResult, a.expectedDescription}
+	return comparison{a.matcher, []any{a.expected[i]}, a.expectedResult, a.expectedDescription}
+}
+
+func (a comparison) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.expected))
 }
 
 // ---
 
 type boolean struct {
+	matcher
+
 	expected bool
 }
 
@@ -352,8 +621,8 @@ func (a boolean) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a boolean) description() string {
-	return "be\n" + indent(1, value{a.expected}.description())
+func (a boolean) description(p *printer) string {
+	return "be\n" + indent(1, value{a.expected}.description(p))
 }
 
 func (a boolean) complexity() int {
@@ -366,7 +635,9 @@ func (a boolean) at(int) Assertion {
 
 // ---
 
-type zero struct{}
+type zero struct {
+	matcher
+}
 
 func (a zero) check(actual []any) ([]bool, error) {
 	result := make([]bool, len(actual))
@@ -377,7 +648,7 @@ func (a zero) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a zero) description() string {
+func (a zero) description(*printer) string {
 	return "be zero"
 }
 
@@ -391,7 +662,9 @@ func (a zero) at(int) Assertion {
 
 // ---
 
-type nilValue struct{}
+type nilValue struct {
+	matcher
+}
 
 func (a nilValue) check(actual []any) ([]bool, error) {
 	result := make([]bool, len(actual))
@@ -415,7 +688,7 @@ func (a nilValue) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a nilValue) description() string {
+func (a nilValue) description(*printer) string {
 	return "be nil"
 }
 
@@ -429,7 +702,9 @@ func (a nilValue) at(int) Assertion {
 
 // ---
 
-type nilError struct{}
+type nilError struct {
+	matcher
+}
 
 func (a nilError) check(actual []any) ([]bool, error) {
 	result := make([]bool, len(actual))
@@ -452,7 +727,7 @@ func (a nilError) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a nilError) description() string {
+func (a nilError) description(*printer) string {
 	return "be\n" + indentSnippet + "<nil>"
 }
 
@@ -467,6 +742,8 @@ func (a nilError) at(int) Assertion {
 // ---
 
 type matchError struct {
+	matcher
+
 	expected error
 }
 
@@ -491,8 +768,8 @@ func (a matchError) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a matchError) description() string {
-	return "be non-nil and match error\n" + indent(1, value{a.expected}.description())
+func (a matchError) description(p *printer) string {
+	return "be non-nil and match error\n" + indent(1, value{a.expected}.description(p))
 }
 
 func (a matchError) complexity() int {
@@ -506,12 +783,14 @@ func (a matchError) at(int) Assertion {
 // ---
 
 type haveLen struct {
+	matcher
+
 	expected []any
 }
 
 func (a haveLen) check(actual []any) ([]bool, error) {
-	if len(a.expected) != len(actual) && len(a.expected) != 1 {
-		return nil, errNumberOfValuesToTestDiffersError{len(actual), len(a.expected)}
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
 	}
 
 	expected := func(i int) any {
@@ -564,14 +843,14 @@ func (a haveLen) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a haveLen) description() string {
+func (a haveLen) description(p *printer) string {
 	if len(a.expected) == 1 {
 		if assertion, ok := a.expected[0].(Assertion); ok {
-			return "have length that is expected to " + assertion.description()
+			return "have length that is expected to " + assertion.description(p)
 		}
 	}
 
-	return "have length\n" + indent(1, values(anySlice(a.expected)).description())
+	return "have length\n" + indent(1, values(anySlice(a.expected)).description(p))
 }
 
 func (a haveLen) complexity() int {
@@ -583,18 +862,37 @@ func (a haveLen) at(i int) Assertion {
 		return a
 	}
 
-	return haveLen{[]any{a.expected[i]}}
+	return haveLen{a.matcher, []any{a.expected[i]}}
+}
+
+func (a haveLen) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.expected))
 }
 
 // ---
 
 type haveField struct {
+	matcher
+
 	name      string
 	assertion Assertion
 }
 
 func (a haveField) check(actual []any) ([]bool, error) {
-	result := make([]bool, len(actual))
+	return verdicts(a.evaluate(actual))
+}
+
+func (a haveField) description(p *printer) string {
+	return fmt.Sprintf("have field %q that is expected to %s", a.name, a.assertion.description(p))
+}
+
+func (a haveField) evaluate(actual []any) ([]outcome, error) {
+	path, err := parseFieldPath(a.name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]outcome, len(actual))
 
 	for i := range actual {
 		v := reflect.ValueOf(actual[i])
@@ -603,29 +901,35 @@ func (a haveField) check(actual []any) ([]bool, error) {
 			v = v.Elem()
 		}
 
-		switch v.Kind() {
-		case reflect.Struct:
-			field := v.FieldByName(a.name)
-			if field.IsValid() {
-				r, err := a.assertion.check([]any{field.Interface()})
-				if err != nil {
-					return nil, err
-				}
+		if v.Kind() != reflect.Struct && path[0].kind == stepField {
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "struct"}
+		}
 
-				result[i] = r[0]
+		field, failure := path.navigate(v)
+		if failure != nil {
+			result[i] = outcome{
+				description: a,
+				details:     fmt.Sprintf("Cannot resolve field %s: %s", failure.path, failure.reason),
 			}
-		default:
-			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "struct"}
+
+			continue
+		}
+
+		inner, err := evaluate(a.assertion, []any{field.Interface()})
+		if err != nil {
+			return nil, err
 		}
+
+		result[i] = inner[0]
+		result[i].description = a
+		result[i].got = field.Interface()
+		result[i].subValue = true
+		result[i].redacted = path.redacts(reflect.ValueOf(actual[i]))
 	}
 
 	return result, nil
 }
 
-func (a haveField) description() string {
-	return fmt.Sprintf("have field %q that is expected to %s", a.name, a.assertion.description())
-}
-
 func (a haveField) complexity() int {
 	return 1
 }
@@ -637,61 +941,234 @@ func (a haveField) at(int) Assertion {
 // ---
 
 type contain struct {
+	matcher
+
 	assertion Assertion
+	n         int
+	quantity  quantity
 }
 
 func (a contain) check(actual []any) ([]bool, error) {
-	result := make([]bool, len(actual))
+	return verdicts(a.evaluate(actual))
+}
 
-	for i := range actual {
-		v := reflect.ValueOf(actual[i])
+func (a contain) description(p *printer) string {
+	if a.quantity == atLeast && a.n == 1 {
+		return fmt.Sprintf("contain an element that is expected to\n%s", indent(1, a.assertion.description(p)))
+	}
 
-		for v.Kind() == reflect.Ptr {
-			v = v.Elem()
+	noun := "elements that are"
+	if a.n == 1 {
+		noun = "element that is"
+	}
+
+	return fmt.Sprintf("contain %s %d %s expected to\n%s", a.quantity, a.n, noun, indent(1, a.assertion.description(p)))
+}
+
+func (a contain) complexity() int {
+	return 2
+}
+
+func (a contain) at(int) Assertion {
+	return a
+}
+
+// evaluate tests each element once and stops as soon as the assertion is satisfied.
+// Otherwise, it keeps the outcomes of all elements to report the matching or the closest non-matching ones.
+func (a contain) evaluate(actual []any) ([]outcome, error) {
+	result := make([]outcome, len(actual))
+
+	for i := range actual {
+		elements, err := a.elements(i, actual[i])
+		if err != nil {
+			return nil, err
 		}
 
-		switch v.Kind() {
-		case reflect.Array, reflect.Slice:
-			for j := range v.Len() {
-				ok, err := a.assertion.check([]any{v.Index(j).Interface()})
-				if err != nil {
-					return nil, err
-				}
+		outcomes := make([]outcome, len(elements))
+
+		var matches []int
+
+		for j := range elements {
+			o, err := evaluate(a.assertion, []any{valueOf(elements[j])})
+			if err != nil {
+				return nil, err
+			}
 
-				if ok[0] {
-					result[i] = true
+			outcomes[j] = o[0]
 
+			if o[0].passed {
+				matches = append(matches, j)
+				if a.quantity == atLeast && len(matches) >= a.n {
 					break
 				}
 			}
-		default:
-			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "array or slice"}
+		}
+
+		result[i] = outcome{description: a, passed: a.quantity.satisfied(len(matches), a.n)}
+		if !result[i].passed {
+			result[i].details, result[i].children = a.explainMatches(elements, outcomes, matches)
 		}
 	}
 
 	return result, nil
 }
 
-func (a contain) description() string {
-	return fmt.Sprintf("contain an element that is expected to\n%s", indent(1, a.assertion.description()))
+// explainMatches describes the matching elements in case there are too many of them
+// or the elements that came closest to matching in case there are too few.
+func (a contain) explainMatches(elements []reflect.Value, outcomes []outcome, matches []int) (string, []outcome) {
+	var children []outcome
+
+	element := func(j int) outcome {
+		o := outcomes[j]
+		o.label = fmt.Sprintf("[%d]", j)
+		o.description = nil
+		o.got = valueOf(elements[j])
+		o.subValue = true
+
+		return o
+	}
+
+	if len(matches) > a.n {
+		for _, j := range matches[:min(len(matches), maxDifferences)] {
+			children = append(children, element(j))
+		}
+
+		return foundMatches(len(matches)), children
+	}
+
+	distances := make([]int, len(outcomes))
+	closest := -1
+
+	for j := range outcomes {
+		distances[j] = outcomes[j].distance()
+		if distances[j] != 0 && (closest == -1 || distances[j] < closest) {
+			closest = distances[j]
+		}
+	}
+
+	for j := range outcomes {
+		if distances[j] != 0 && distances[j] == closest && len(children) < maxClosestElements {
+			children = append(children, element(j))
+		}
+	}
+
+	details := foundMatches(len(matches))
+	if len(children) != 0 {
+		details += ", closest non-matching elements are"
+	}
+
+	return details, children
 }
 
-func (a contain) complexity() int {
-	return 2
+// foundMatches tells how many matching elements were found.
+func foundMatches(n int) string {
+	if n == 1 {
+		return "Found 1 matching element"
+	}
+
+	return fmt.Sprintf("Found %d matching elements", n)
 }
 
-func (a contain) at(int) Assertion {
-	return a
+// elements returns elements of an array or a slice, or a pointer to them.
+func (a contain) elements(i int, actual any) ([]reflect.Value, error) {
+	v := reflect.ValueOf(actual)
+
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Array, reflect.Slice:
+		elements := make([]reflect.Value, v.Len())
+		for j := range elements {
+			elements[j] = v.Index(j)
+		}
+
+		return elements, nil
+	default:
+		return nil, errUnexpectedValueTypeError{i, typeOf(actual), "array or slice"}
+	}
+}
+
+// ---
+
+// quantity is a rule for the number of elements matching an assertion.
+type quantity int
+
+const (
+	atLeast quantity = iota
+	atMost
+	exactly
+)
+
+func (q quantity) satisfied(count, n int) bool {
+	switch q {
+	case atLeast:
+		return count >= n
+	case atMost:
+		return count <= n
+	default:
+		return count == n
+	}
+}
+
+func (q quantity) String() string {
+	switch q {
+	case atLeast:
+		return "at least"
+	case atMost:
+		return "at most"
+	default:
+		return "exactly"
+	}
 }
 
 // ---
 
 type beStruct struct {
+	matcher
+
 	assertions []Assertion
+	strict     bool
 }
 
 func (a beStruct) check(actual []any) ([]bool, error) {
-	result := make([]bool, len(actual))
+	return verdicts(a.evaluate(actual))
+}
+
+func (a beStruct) description(p *printer) string {
+	var sb strings.Builder
+	if a.strict {
+		sb.WriteString("be a struct with all exported fields covered that is expected to\n")
+	} else {
+		sb.WriteString("be a struct that is expected to\n")
+	}
+
+	for i, assertion := range a.assertions {
+		fmt.Fprintf(&sb, "%d. ", i+1)
+		sb.WriteString(indent(1, assertion.description(p)))
+		sb.WriteRune('\n')
+	}
+
+	return sb.String()
+}
+
+func (a beStruct) complexity() int {
+	return 2
+}
+
+func (a beStruct) at(int) Assertion {
+	return a
+}
+
+// evaluate tests the values against all the field assertions, so that each of them is reported.
+func (a beStruct) evaluate(actual []any) ([]outcome, error) {
+	what := phrase("be a struct")
+	if a.strict {
+		what = "be a struct with all exported fields covered"
+	}
+
+	result := make([]outcome, len(actual))
 
 	for i := range actual {
 		v := reflect.ValueOf(actual[i])
@@ -704,49 +1181,102 @@ func (a beStruct) check(actual []any) ([]bool, error) {
 			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "struct"}
 		}
 
-		result[i] = true
+		result[i] = outcome{description: what, passed: true, combine: totalDistance}
+
+		if a.strict {
+			if uncovered := a.uncovered(v.Type()); len(uncovered) != 0 {
+				result[i].passed = false
+				result[i].details = "Fields not covered by any assertion:\n" + indent(1, strings.Join(uncovered, "\n"))
+				result[i].penalty = len(uncovered)
+			}
+		}
 
 		for _, assertion := range a.assertions {
-			ok, err := assertion.check([]any{actual[i]})
+			child, err := evaluate(assertion, actual[i:i+1])
 			if err != nil {
 				return nil, err
 			}
 
-			if !ok[0] {
-				result[i] = false
-
-				break
-			}
+			result[i].passed = result[i].passed && child[0].passed
+			result[i].children = append(result[i].children, child[0])
 		}
 	}
 
 	return result, nil
 }
 
-func (a beStruct) description() string {
-	var sb strings.Builder
-	sb.WriteString("be a struct that is expected to\n")
+// uncovered returns names of exported fields of struct type t that are not covered by any of the field assertions,
+// including the ones nested in [Not], [And], [Or] and [Struct] assertions.
+func (a beStruct) uncovered(t reflect.Type) []string {
+	covered := make(map[string]bool, len(a.assertions))
+	coverFields(covered, a.assertions...)
 
-	for i, assertion := range a.assertions {
-		fmt.Fprintf(&sb, "%d. ", i+1)
-		sb.WriteString(indent(1, assertion.description()))
-		sb.WriteRune('\n')
+	var result []string
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.IsExported() && !covered[field.Name] {
+			result = append(result, field.Name)
+		}
 	}
 
-	return sb.String()
+	return result
 }
 
-func (a beStruct) complexity() int {
-	return 2
+// coverFields marks the top-level fields tested by the field assertions as covered,
+// looking into the assertions that test the same value.
+func coverFields(covered map[string]bool, assertions ...Assertion) {
+	for _, assertion := range assertions {
+		switch assertion := assertion.(type) {
+		case haveField:
+			path, err := parseFieldPath(assertion.name)
+			if err == nil && path[0].kind == stepField {
+				covered[path[0].name] = true
+			}
+		case not:
+			coverFields(covered, assertion.assertion)
+		case and:
+			coverFields(covered, assertion.assertions...)
+		case or:
+			coverFields(covered, assertion.assertions...)
+		case beStruct:
+			coverFields(covered, assertion.assertions...)
+		}
+	}
 }
 
-func (a beStruct) at(int) Assertion {
+// ---
+
+type anything struct {
+	matcher
+}
+
+func (a anything) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+	for i := range result {
+		result[i] = true
+	}
+
+	return result, nil
+}
+
+func (a anything) description(*printer) string {
+	return "be anything"
+}
+
+func (a anything) complexity() int {
+	return 1
+}
+
+func (a anything) at(int) Assertion {
 	return a
 }
 
 // ---
 
 type not struct {
+	matcher
+
 	assertion Assertion
 }
 
@@ -763,16 +1293,16 @@ func (a not) check(actual []any) ([]bool, error) {
 	return result, nil
 }
 
-func (a not) description() string {
+func (a not) description(p *printer) string {
 	if inner, ok := a.assertion.(not); ok {
-		return inner.assertion.description()
+		return inner.assertion.description(p)
 	}
 
 	if a.assertion.complexity() > 1 {
-		return "not\n" + indent(1, a.assertion.description())
+		return "not\n" + indent(1, a.assertion.description(p))
 	}
 
-	return "not " + a.assertion.description()
+	return "not " + a.assertion.description(p)
 }
 
 func (a not) complexity() int {
@@ -780,72 +1310,126 @@ func (a not) complexity() int {
 }
 
 func (a not) at(i int) Assertion {
-	return Not(a.assertion.at(i))
+	return not{a.matcher, a.assertion.at(i)}
+}
+
+func (a not) checkArity(n int) error {
+	return checkArity(a.assertion, n)
 }
 
 // ---
 
 type and struct {
+	matcher
+
 	assertions []Assertion
 }
 
 func (a and) check(actual []any) ([]bool, error) {
-	return combineAssertionChecks(a.assertions, actual, true, func(x, y bool) bool {
-		return x && y
-	})
+	return verdicts(a.evaluate(actual))
 }
 
-func (a and) description() string {
-	return combineAssertionDescriptions("and", a.assertions)
+func (a and) description(p *printer) string {
+	return combineAssertionDescriptions(p, "and", a.assertions)
 }
 
 func (a and) complexity() int {
 	return len(a.assertions)
 }
 
-func (a and) at(int) Assertion {
-	assertions := make([]Assertion, len(a.assertions))
-	for i := range a.assertions {
-		assertions[i] = a.assertions[i].at(i)
-	}
+func (a and) at(i int) Assertion {
+	return and{a.matcher, assertionsAt(a.assertions, i)}
+}
 
-	return and{assertions}
+func (a and) checkArity(n int) error {
+	return checkCombinedArity(a.assertions, n)
+}
+
+func (a and) evaluate(actual []any) ([]outcome, error) {
+	return combineAssertionOutcomes("pass all of", a.assertions, actual, false)
 }
 
 // ---
 
 type or struct {
+	matcher
+
 	assertions []Assertion
 }
 
 func (a or) check(actual []any) ([]bool, error) {
-	return combineAssertionChecks(a.assertions, actual, false, func(x, y bool) bool {
-		return x || y
-	})
+	return verdicts(a.evaluate(actual))
 }
 
-func (a or) description() string {
-	return combineAssertionDescriptions("or", a.assertions)
+func (a or) description(p *printer) string {
+	return combineAssertionDescriptions(p, "or", a.assertions)
 }
 
 func (a or) complexity() int {
 	return len(a.assertions)
 }
 
-func (a or) at(int) Assertion {
-	assertions := make([]Assertion, len(a.assertions))
-	for i := range a.assertions {
-		assertions[i] = a.assertions[i].at(i)
+func (a or) at(i int) Assertion {
+	return or{a.matcher, assertionsAt(a.assertions, i)}
+}
+
+func (a or) checkArity(n int) error {
+	return checkCombinedArity(a.assertions, n)
+}
+
+func (a or) evaluate(actual []any) ([]outcome, error) {
+	return combineAssertionOutcomes("pass any of", a.assertions, actual, true)
+}
+
+// ---
+
+// multiValueAssertion is an optional interface that is implemented by an [Assertion]
+// that can have a separate expected value for each of the values to test.
+type multiValueAssertion interface {
+	checkArity(n int) error
+}
+
+// checkArity checks that the assertion can be used to test n values.
+func checkArity(assertion Assertion, n int) error {
+	if assertion, ok := assertion.(multiValueAssertion); ok {
+		return assertion.checkArity(n)
+	}
+
+	return nil
+}
+
+func checkNumberOfValues(actual, expected int) error {
+	if expected != actual && expected != 1 {
+		return errNumberOfValuesToTestDiffersError{actual, expected}
+	}
+
+	return nil
+}
+
+func checkCombinedArity(assertions []Assertion, n int) error {
+	for _, assertion := range assertions {
+		if err := checkArity(assertion, n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func assertionsAt(assertions []Assertion, i int) []Assertion {
+	result := make([]Assertion, len(assertions))
+	for j := range assertions {
+		result[j] = assertions[j].at(i)
 	}
 
-	return or{assertions}
+	return result
 }
 
 // ---
 
-func combineAssertionDescriptions(operator string, assertions []Assertion) string {
+func combineAssertionDescriptions(p *printer, operator string, assertions []Assertion) string {
 	if len(assertions) == 1 {
-		return assertions[0].description()
+		return assertions[0].description(p)
 	}
 
 	var sb strings.Builder
@@ -857,7 +1441,7 @@ func combineAssertionDescriptions(operator string, assertions []Assertion) strin
 			sb.WriteRune(' ')
 		}
 
-		desc := assertion.description()
+		desc := assertion.description(p)
 
 		if assertion.complexity() > 1 {
 			sb.WriteString("\n")
@@ -870,22 +1454,74 @@ func combineAssertionDescriptions(operator string, assertions []Assertion) strin
 	return sb.String()
 }
 
-func combineAssertionChecks(assertions []Assertion, actual []any, initial bool, operator func(bool, bool) bool) ([]bool, error) {
+// combineAssertionOutcomes evaluates each of the values against the assertions one by one
+// until the result for the value is decided, that is until an assertion returns the decisive result for it.
+// Each assertion is evaluated at most once for each value, and the ones following the decisive result are skipped.
+// The first assertion is evaluated against all the values at once,
+// and the following assertions are evaluated only against the values that are still undecided.
+func combineAssertionOutcomes(what phrase, assertions []Assertion, actual []any, decisive bool) ([]outcome, error) {
 	if len(assertions) == 0 {
-		return nil, errors.New("expected 1 or more assertion in composite assertion")
+		return nil, errNoAssertionsInComposite
 	}
 
-	result := make([]bool, len(actual))
-	for i := range result {
-		result[i] = initial
+	if err := checkCombinedArity(assertions, len(actual)); err != nil {
+		return nil, err
+	}
+
+	if len(assertions) == 1 {
+		return evaluate(assertions[0], actual)
+	}
+
+	combine := totalDistance
+	if decisive {
+		combine = minDistance
+	}
+
+	result := make([]outcome, len(actual))
+	decided := make([]bool, len(actual))
+	undecided := len(actual)
+
+	for i := range actual {
+		result[i] = outcome{description: what, passed: !decisive, combine: combine}
+	}
 
-		for j := range assertions {
-			ok, err := assertions[j].check(actual)
+	for _, assertion := range assertions {
+		var outcomes []outcome
+
+		if undecided == len(actual) {
+			var err error
+
+			outcomes, err = evaluate(assertion, actual)
 			if err != nil {
 				return nil, err
 			}
+		} else {
+			outcomes = make([]outcome, len(actual))
+
+			for i := range actual {
+				if decided[i] {
+					outcomes[i] = outcome{description: assertion.at(i), skipped: true}
+
+					continue
+				}
 
-			result[i] = operator(result[i], ok[i])
+				o, err := evaluate(assertion.at(i), actual[i:i+1])
+				if err != nil {
+					return nil, err
+				}
+
+				outcomes[i] = o[0]
+			}
+		}
+
+		for i := range actual {
+			result[i].children = append(result[i].children, outcomes[i])
+
+			if !decided[i] && outcomes[i].passed == decisive {
+				result[i].passed = decisive
+				decided[i] = true
+				undecided--
+			}
 		}
 	}
 
@@ -910,6 +1546,10 @@ func compare(i int, actual, expected reflect.Value) (int, error) {
 		return result, nil
 	}
 
+	if result, ok := compareUsingMethods(actual, expected); ok {
+		return succeed(result)
+	}
+
 	switch actual.Kind() {
 	case reflect.Bool:
 		if actual.Kind() != expected.Kind() {
@@ -938,7 +1578,7 @@ func compare(i int, actual, expected reflect.Value) (int, error) {
 
 			return succeed(cmp.Compare(uint64(actual), expected.Uint()))
 		default:
-			return fail()
+			return compareNumeric(i, actual, expected)
 		}
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
@@ -953,15 +1593,15 @@ func compare(i int, actual, expected reflect.Value) (int, error) {
 
 			return succeed(cmp.Compare(actual.Uint(), uint64(expected)))
 		default:
-			return fail()
+			return compareNumeric(i, actual, expected)
 		}
 
 	case reflect.Float32, reflect.Float64:
 		switch expected.Kind() {
 		case reflect.Float32, reflect.Float64:
-			return succeed(cmp.Compare(actual.Float(), expected.Float()))
+			return succeed(compareFloats(actual.Float(), expected.Float()))
 		default:
-			return fail()
+			return compareNumeric(i, actual, expected)
 		}
 
 	case reflect.String:
@@ -972,16 +1612,63 @@ func compare(i int, actual, expected reflect.Value) (int, error) {
 		return succeed(strings.Compare(actual.String(), expected.String()))
 
 	default:
-		return fail()
+		return compareNumeric(i, actual, expected)
+	}
+}
+
+// compareUsingMethods compares actual and expected values using either `Compare(T) int` method
+// or a pair of `Before(T) bool` and `After(T) bool` methods of the actual value, if it has any of them.
+func compareUsingMethods(actual, expected reflect.Value) (int, bool) {
+	if !actual.IsValid() || !expected.IsValid() || !actual.CanInterface() || !expected.CanInterface() {
+		return 0, false
+	}
+
+	switch actual.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if actual.IsNil() {
+			return 0, false
+		}
+	}
+
+	method := func(name string, out reflect.Kind) reflect.Value {
+		m := actual.MethodByName(name)
+		if !m.IsValid() {
+			return reflect.Value{}
+		}
+
+		mt := m.Type()
+		if mt.NumIn() != 1 || !expected.Type().AssignableTo(mt.In(0)) || mt.NumOut() != 1 || mt.Out(0).Kind() != out {
+			return reflect.Value{}
+		}
+
+		return m
+	}
+
+	if compare := method("Compare", reflect.Int); compare.IsValid() {
+		return cmp.Compare(compare.Call([]reflect.Value{expected})[0].Int(), 0), true
+	}
+
+	before, after := method("Before", reflect.Bool), method("After", reflect.Bool)
+	if before.IsValid() && after.IsValid() {
+		switch {
+		case before.Call([]reflect.Value{expected})[0].Bool():
+			return -1, true
+		case after.Call([]reflect.Value{expected})[0].Bool():
+			return 1, true
+		default:
+			return 0, true
+		}
 	}
+
+	return 0, false
 }
 
 func lt(r int) bool {
-	return r < 0
+	return r < 0 && r != unordered
 }
 
 func le(r int) bool {
-	return r <= 0
+	return r <= 0 && r != unordered
 }
 
 func gt(r int) bool {
@@ -991,3 +1678,7 @@ func gt(r int) bool {
 func ge(r int) bool {
 	return r >= 0
 }
+
+var anonymousFunc = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+const maxClosestElements = 3
@@ -0,0 +1,85 @@
+package tst
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Colors returns a format option that enables or disables ANSI colors in failure messages.
+//
+// By default, colors are used only if the standard output of the test binary is a terminal.
+// Note that `go test` captures the output of test binaries, so colors are disabled by default when running tests that way.
+// Set FORCE_COLOR to a non-empty value or TST_COLOR=always to enable them, see [New] for other settings that change the default.
+func Colors(enabled bool) FormatOption {
+	return func(p *printer) {
+		p.colors = colorNever
+		if enabled {
+			p.colors = colorAlways
+		}
+	}
+}
+
+// ---
+
+type colorMode int
+
+const (
+	colorAuto colorMode = iota
+	colorNever
+	colorAlways
+)
+
+// ---
+
+// colored reports whether the printer uses ANSI colors.
+func (p *printer) colored() bool {
+	switch p.colors {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	default:
+		return autoColors()
+	}
+}
+
+// paint wraps each non-empty line of the text with the specified ANSI color sequence if colors are used.
+func (p *printer) paint(color, text string) string {
+	if !p.colored() || text == "" {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if trimmed := strings.TrimLeft(line, " "); trimmed != "" {
+			lines[i] = line[:len(line)-len(trimmed)] + color + trimmed + colorReset
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// unpaint removes the ANSI color sequences added by [printer.paint] from the text.
+func unpaint(text string) string {
+	return colorRemover.Replace(text)
+}
+
+// ---
+
+// autoColors reports whether colors are used by default, that is whether the standard output is a terminal.
+// It is usually not the case under `go test`, which captures the output of test binaries.
+var autoColors = sync.OnceValue(func() bool {
+	info, err := os.Stdout.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+})
+
+const (
+	colorReset    = "\x1b[0m"
+	colorActual   = "\x1b[31m"
+	colorExpected = "\x1b[32m"
+	colorMarker   = "\x1b[1;33m"
+)
+
+var colorRemover = strings.NewReplacer(colorReset, "", colorActual, "", colorExpected, "", colorMarker, "")
@@ -0,0 +1,35 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestColors(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(42).WithFormat(tst.Colors(true)).ToEqual(43)
+	})
+	if !failed {
+		t.Fatalf("Expected ToEqual to fail")
+	}
+
+	for _, expected := range []string{
+		"\x1b[31m<int>: 42\x1b[0m",
+		"\x1b[32mequal to\x1b[0m",
+		"\x1b[32m<int>: 43\x1b[0m",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain %q, got output: %q", expected, output)
+		}
+	}
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(42).WithFormat(tst.Colors(false)).ToEqual(43)
+	})
+
+	if strings.Contains(output, "\x1b[") {
+		t.Fatalf("Expected output without colors, got output: %q", output)
+	}
+}
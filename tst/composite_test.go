@@ -0,0 +1,164 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestCompositeEvaluatesEachAssertionOnce(t *testing.T) {
+	calls := 0
+	counting := func(a, b int) bool {
+		calls++
+
+		return a == b
+	}
+
+	_, failed := run(func(t tst.Test) {
+		t.Expect(1, 2, 3).ToNot(tst.And(tst.BeLessThan(2), tst.EqualBy(counting, 0)))
+	})
+	if failed {
+		t.Fatalf("Expected And to fail for all values")
+	}
+
+	if calls != 1 {
+		t.Fatalf("Expected the second assertion to be evaluated only for undecided values, got %d calls", calls)
+	}
+
+	calls = 0
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect(1, 2, 3).To(tst.Or(tst.EqualBy(counting, 1, 5, 6), tst.BeGreaterThan(1)))
+	})
+	if failed {
+		t.Fatalf("Expected Or to pass for all values")
+	}
+
+	if calls != 3 {
+		t.Fatalf("Expected the first assertion to be evaluated once for each value, got %d calls", calls)
+	}
+}
+
+func TestCompositeUsesValueIndex(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(1, 3).To(tst.And(tst.BeGreaterThan(0), tst.Equal(1, 2)))
+	})
+	if !failed {
+		t.Fatalf("Expected And to fail")
+	}
+
+	if !strings.Contains(output, "value #2") || strings.Contains(output, "value #1") {
+		t.Fatalf("Expected only value #2 to fail, got output: %s", output)
+	}
+
+	if !strings.Contains(output, "✓ be greater than\n      <int>: 0\n✗ equal to\n      <int>: 2") {
+		t.Fatalf("Expected failed branch to be reported, got output: %s", output)
+	}
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect(1, 2, 3).To(tst.Or(tst.Equal(1, 2), tst.BeTrue()))
+	})
+	if !failed {
+		t.Fatalf("Expected Or with mismatched number of values to fail")
+	}
+}
+
+func TestCompositeReportsBranches(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect([]int{2, 1}).To(tst.Or(tst.BeSorted(), tst.HaveLen(3)))
+	})
+	if !failed {
+		t.Fatalf("Expected Or to fail")
+	}
+
+	if !strings.Contains(output, "✗ be sorted\n    Elements out of order:") || !strings.Contains(output, "✗ have length") {
+		t.Fatalf("Expected branches to be reported, got output: %s", output)
+	}
+}
+
+func TestStructReportsFields(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect([]account{{ID: 1}, {ID: 2, Name: "bob"}}).To(tst.Contain(tst.Struct(
+			tst.Field("ID", tst.Equal(2)),
+			tst.Field("Name", tst.Or(tst.Equal("alice"), tst.HavePrefix("a"))),
+		)))
+	})
+	if !failed {
+		t.Fatalf("Expected Contain to fail")
+	}
+
+	expected := strings.Join([]string{
+		"✗ [1]",
+		"    got <tst_test.account>: tst_test.account{ID: 2, Name: \"bob\", Email: \"\", balance: 0}",
+		"    ✓ have field \"ID\" that is expected to equal to",
+		"          <int>: 2",
+		"    ✗ have field \"Name\" that is expected to equal to",
+		"          <string>: [5] \"alice\"",
+		"      or have prefix",
+		"          <string>: [1] \"a\"",
+		"        got <string>: [3] \"bob\"",
+		"        ✗ equal to",
+	}, "\n")
+
+	if !strings.Contains(output, expected) {
+		t.Fatalf("Expected failed field to be reported, got output: %s", output)
+	}
+}
+
+func TestCompositeReportEvaluatesEachAssertionOnce(t *testing.T) {
+	calls := 0
+	counting := func(a, b int) bool {
+		calls++
+
+		return a == b
+	}
+
+	for _, events := range []bool{false, true} {
+		calls = 0
+
+		m := &mockT{}
+		tt := tst.New(m, tst.Events(events), tst.Soft(true), tst.WithFormat(tst.Colors(false)))
+		tt.Expect(1).To(tst.And(tst.EqualUsing(counting, 2), tst.EqualUsing(counting, 1)))
+
+		if !m.failed {
+			t.Fatalf("Expected And to fail")
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected only the first assertion to be evaluated with events %t, got %d calls", events, calls)
+		}
+
+		if !strings.Contains(m.output(), "✗ equal using") || !strings.Contains(m.output(), "\n- equal using") {
+			t.Fatalf("Expected the second assertion to be reported as skipped, got output: %s", m.output())
+		}
+	}
+
+	calls = 0
+
+	_, failed := run(func(t tst.Test) {
+		t.Expect(1).To(tst.And(tst.Or(tst.EqualBy(counting, 2), tst.EqualBy(counting, 3)), tst.EqualBy(counting, 1)))
+	})
+	if !failed {
+		t.Fatalf("Expected And to fail")
+	}
+
+	if calls != 2 {
+		t.Fatalf("Expected 2 calls to evaluate Or and none to report the failure, got %d calls", calls)
+	}
+
+	calls = 0
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect([]account{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}).To(tst.Contain(tst.Struct(
+			tst.Field("ID", tst.EqualBy(counting, 6)),
+		)))
+	})
+	if !failed {
+		t.Fatalf("Expected Contain to fail")
+	}
+
+	if calls != 5 {
+		t.Fatalf("Expected a single call for each element, got %d calls", calls)
+	}
+}
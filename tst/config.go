@@ -0,0 +1,179 @@
+package tst
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FullPath returns a test option that makes line tags of the test rendered with full file paths if enabled,
+// or with the last path elements otherwise.
+func FullPath(enabled bool) Option {
+	return func(c *core) {
+		p := *c.printer
+		p.fullPath = enabled
+		c.printer = &p
+	}
+}
+
+// Soft returns a test option that makes failed expectations of the test
+// mark it as failed and let it continue instead of stopping it, if enabled.
+// Expectations that cannot be tested, like the ones with a wrong number of values,
+// and [Expectation.ToSucceed] still stop the test.
+func Soft(enabled bool) Option {
+	return func(c *core) {
+		c.soft = enabled
+	}
+}
+
+// ---
+
+// defaults returns a core configured by package defaults, environment variables and test flags.
+func defaults() (core, error) {
+	c := core{printer: defaultPrinter}
+
+	configure, err := environment()
+	if err != nil {
+		return c, err
+	}
+
+	configure(&c)
+
+	return c, nil
+}
+
+// environment returns an option applying the settings from environment variables and test flags.
+// It is resolved on each call, so it does not depend on whether the flags are already parsed.
+func environment() (Option, error) {
+	var options []Option
+
+	add := func(s setting, source, value string) error {
+		option, err := s.parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q of %s: %w", value, source, err)
+		}
+
+		options = append(options, option)
+
+		return nil
+	}
+
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok && s.env != "" {
+			err := add(s, "environment variable "+s.env, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := commandLineFlag(s.flag); ok && s.flag != "" {
+			err := add(s, "flag -"+s.flag, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return func(c *core) {
+		p := *c.printer
+		c.printer = &p
+
+		for _, option := range options {
+			option(c)
+		}
+	}, nil
+}
+
+// commandLineFlag returns the value of the last test flag with the name found in the command line arguments.
+// Flags are looked up in [os.Args] instead of being registered in the [flag] package,
+// so importing the package does not add flags to the binaries importing it.
+// Both `-name=value` and `--name=value` forms are accepted, and a flag without a value means `true`.
+func commandLineFlag(name string) (string, bool) {
+	var value string
+
+	found := false
+
+	for _, arg := range os.Args[1:] {
+		arg, ok := strings.CutPrefix(arg, "-")
+		if !ok {
+			continue
+		}
+
+		arg = strings.TrimPrefix(arg, "-")
+
+		switch key, v, hasValue := strings.Cut(arg, "="); {
+		case key != name:
+		case hasValue:
+			value, found = v, true
+		default:
+			value, found = "true", true
+		}
+	}
+
+	return value, found
+}
+
+// setting describes a setting that can be set by an environment variable and a test flag.
+type setting struct {
+	env   string
+	flag  string
+	parse func(value string) (Option, error)
+}
+
+var settings = []setting{
+	{"TST_FULL_PATH", "test.fullpath", boolSetting(func(c *core, value bool) { c.printer.fullPath = value })},
+	{"FORCE_COLOR", "", presenceSetting(func(c *core) { c.printer.colors = colorAlways })},
+	{"NO_COLOR", "", presenceSetting(func(c *core) { c.printer.colors = colorNever })},
+	{"TST_COLOR", "tst.color", colorSetting},
+	{"TST_MAX_DEPTH", "tst.maxdepth", intSetting(func(c *core, value int) { MaxDepth(value)(c.printer) })},
+	{"TST_MAX_LENGTH", "tst.maxlength", intSetting(func(c *core, value int) { MaxLength(value)(c.printer) })},
+	{"TST_MAX_STRING_LENGTH", "tst.maxstringlength", intSetting(func(c *core, value int) { MaxStringLength(value)(c.printer) })},
+	{"TST_SOFT", "tst.soft", boolSetting(func(c *core, value bool) { c.soft = value })},
+	{"TST_EVENTS", "tst.events", boolSetting(func(c *core, value bool) { c.events = value })},
+	{"TST_TRACE", "tst.trace", boolSetting(func(c *core, value bool) { c.trace = value })},
+}
+
+func boolSetting(apply func(*core, bool)) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(c *core) { apply(c, v) }, nil
+	}
+}
+
+func intSetting(apply func(*core, int)) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(c *core) { apply(c, v) }, nil
+	}
+}
+
+func presenceSetting(apply func(*core)) func(string) (Option, error) {
+	return func(value string) (Option, error) {
+		if value == "" {
+			return func(*core) {}, nil
+		}
+
+		return apply, nil
+	}
+}
+
+func colorSetting(value string) (Option, error) {
+	mode, ok := map[string]colorMode{"auto": colorAuto, "always": colorAlways, "never": colorNever}[value]
+	if !ok {
+		return nil, errors.New("expected auto, always or never")
+	}
+
+	return func(c *core) { c.printer.colors = mode }, nil
+}
@@ -0,0 +1,96 @@
+package tst_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestSoft(t *testing.T) {
+	m := &mockT{}
+
+	tt := tst.New(m, tst.Soft(true))
+	tt.Expect(1).ToEqual(2)
+	tt.Expect(3).ToEqual(3)
+	tt.Expect(4).ToEqual(5)
+
+	if !m.failed {
+		t.Fatalf("Expected the test to fail")
+	}
+
+	if n := strings.Count(m.output(), "\nExpected"); n != 2 {
+		t.Fatalf("Expected 2 failure messages, got %d in output: %s", n, m.output())
+	}
+
+	_, failed := run(func(t tst.Test) {
+		t.Expect(1).ToEqual(2)
+		panic("unreachable")
+	})
+	if !failed {
+		t.Fatalf("Expected the test to fail")
+	}
+}
+
+func TestFullPath(t *testing.T) {
+	for _, fullPath := range []bool{false, true} {
+		m := &mockT{}
+
+		tst.New(m, tst.FullPath(fullPath)).Run("sub", func(t tst.Test) {
+			t.AddLineTags(tst.ThisLine())
+			t.Fail()
+		})
+
+		for _, f := range m.cleanup {
+			f()
+		}
+
+		line := strings.TrimPrefix(m.output(), "See ")
+		if filepath.IsAbs(line) != fullPath || !strings.HasSuffix(line, "tst/config_test.go:43") {
+			t.Fatalf("Expected line tag with full path %t, got output: %s", fullPath, m.output())
+		}
+	}
+}
+
+func TestCommandLineFlags(t *testing.T) {
+	args := os.Args
+	t.Cleanup(func() { os.Args = args })
+
+	t.Setenv("TST_SOFT", "false")
+	t.Setenv("TST_MAX_STRING_LENGTH", "100")
+
+	os.Args = []string{args[0], "-test.v=true", "--", "-tst.soft", "--tst.maxstringlength=3", "-tst.color=always"}
+
+	m := &mockT{}
+
+	tt := tst.New(m)
+	tt.Expect("abcdef").ToEqual("x")
+
+	if !m.failed {
+		t.Fatalf("Expected the test to fail")
+	}
+
+	if output := m.output(); !strings.Contains(output, `"abc"…`) || !strings.Contains(output, "\x1b[") {
+		t.Fatalf("Expected truncated and colored output, got: %s", output)
+	}
+
+	os.Args = []string{args[0], "--", "-tst.maxdepth=x"}
+
+	output, failed := run(func(t tst.Test) {
+		tst.New(t)
+	})
+	if !failed || !strings.Contains(output, `invalid value "x" of flag -tst.maxdepth`) {
+		t.Fatalf("Expected invalid flag to fail the test, got output: %s", output)
+	}
+}
+
+func TestNoFlagsRegistered(t *testing.T) {
+	flag.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, "tst.") {
+			t.Errorf("Expected no tst flags to be registered, got -%s", f.Name)
+		}
+	})
+}
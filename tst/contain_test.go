@@ -0,0 +1,86 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestContainCounts(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name      string
+		assertion tst.Assertion
+		ok        bool
+	}{
+		{"contain", tst.Contain(tst.Equal(3)), true},
+		{"contain-none", tst.Contain(tst.Equal(6)), false},
+		{"exactly", tst.ContainN(2, tst.BeGreaterThan(3)), true},
+		{"exactly-too-many", tst.ContainN(2, tst.BeGreaterThan(2)), false},
+		{"exactly-too-few", tst.ContainN(2, tst.BeGreaterThan(4)), false},
+		{"exactly-zero", tst.ContainN(0, tst.BeGreaterThan(5)), true},
+		{"at-least", tst.ContainAtLeast(3, tst.BeLessThan(4)), true},
+		{"at-least-too-few", tst.ContainAtLeast(4, tst.BeLessThan(4)), false},
+		{"at-most", tst.ContainAtMost(3, tst.BeLessThan(4)), true},
+		{"at-most-too-many", tst.ContainAtMost(2, tst.BeLessThan(4)), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, failed := run(func(t tst.Test) {
+				t.Expect(values).To(tc.assertion)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected assertion to pass = %v", tc.ok)
+			}
+		})
+	}
+}
+
+func TestContainReportsClosestElements(t *testing.T) {
+	values := []record{
+		{ID: 1, Name: "a", Tags: []string{"x"}},
+		{ID: 2, Name: "b", Tags: []string{"y"}},
+		{ID: 3, Name: "c", Tags: []string{"z"}},
+	}
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(values).To(tst.Contain(tst.Equal(record{ID: 2, Name: "b", Tags: []string{"w"}})))
+	})
+	if !failed {
+		t.Fatalf("Expected Contain to fail")
+	}
+
+	if !strings.Contains(output, "Found 0 matching elements, closest non-matching elements are\n✗ [1]\n") {
+		t.Fatalf("Expected closest element to be reported, got output: %s", output)
+	}
+
+	if strings.Contains(output, "✗ [0]") || strings.Contains(output, "✗ [2]") {
+		t.Fatalf("Expected only the closest element to be reported, got output: %s", output)
+	}
+
+	output, failed = run(func(t tst.Test) {
+		t.Expect([]int{1, 2, 3}).To(tst.ContainAtMost(1, tst.BeLessThan(3)))
+	})
+	if !failed {
+		t.Fatalf("Expected ContainAtMost to fail")
+	}
+
+	if !strings.Contains(output, "to contain at most 1 element that is expected to\n") ||
+		!strings.Contains(output, "Found 2 matching elements\n✓ [0]\n✓ [1]") {
+		t.Fatalf("Expected matching elements to be reported, got output: %s", output)
+	}
+
+	output, failed = run(func(t tst.Test) {
+		t.Expect([]int{1, 2, 3}).To(tst.ContainN(2, tst.BeLessThan(2)))
+	})
+	if !failed {
+		t.Fatalf("Expected ContainN to fail")
+	}
+
+	if !strings.Contains(output, "Found 1 matching element, closest non-matching elements are\n") {
+		t.Fatalf("Expected the single matching element to be counted, got output: %s", output)
+	}
+}
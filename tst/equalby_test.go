@@ -0,0 +1,90 @@
+package tst_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestEqualByNil(t *testing.T) {
+	is := func(a, b error) bool {
+		return errors.Is(a, b)
+	}
+
+	tests := []struct {
+		name      string
+		actual    any
+		assertion tst.Assertion
+		ok        bool
+	}{
+		{"typed-nil-actual", error(nil), tst.EqualBy(is, nil), true},
+		{"typed-nil-vs-error", error(nil), tst.EqualBy(is, io.EOF), false},
+		{"typed-error", io.EOF, tst.EqualBy(is, io.EOF), true},
+		{"typed-nil-pointer", (*int)(nil), tst.EqualBy(func(a, b *int) bool { return a == b }, nil), true},
+		{"reflective-nil-actual", nil, tst.EqualUsing(is, io.EOF), false},
+		{"reflective-nil-expected", io.EOF, tst.EqualUsing(is, nil), false},
+		{"reflective-nil-both", nil, tst.EqualUsing(is, nil), true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected assertion to pass = %v, got output: %s", tc.ok, output)
+			}
+		})
+	}
+}
+
+func TestEqualByNilNonNillable(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(nil).To(tst.EqualBy(func(a, b int) bool { return a == b }, 1))
+	})
+	if !failed || !strings.Contains(output, "nil") {
+		t.Fatalf("Expected an error about nil value, got output: %s", output)
+	}
+}
+
+func TestEqualByKeepsComparatorAt(t *testing.T) {
+	sameParity := func(a, b int) bool {
+		return a%2 == b%2
+	}
+
+	for _, assertion := range []tst.Assertion{tst.EqualBy(sameParity, 3, 4), tst.EqualUsing(sameParity, 3, 4)} {
+		output, failed := run(func(t tst.Test) {
+			t.Expect(1, 5).To(assertion)
+		})
+		if !failed {
+			t.Fatalf("Expected assertion to fail")
+		}
+
+		if strings.Contains(output, "value #1") || !strings.Contains(output, "value #2") {
+			t.Fatalf("Expected only value #2 to fail, got output: %s", output)
+		}
+
+		if !strings.Contains(output, "to equal using ") || !strings.Contains(output, "<int>: 4") || strings.Contains(output, "<int>: 3") {
+			t.Fatalf("Expected description with the comparator and the value #2 only, got output: %s", output)
+		}
+	}
+}
+
+func TestEqualUsingMismatchedPositions(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(1, "two").To(tst.EqualUsing(func(a, b int) bool { return a == b }, 1, 2))
+	})
+	if !failed || !strings.Contains(output, "string") {
+		t.Fatalf("Expected an error about the type of value #2, got output: %s", output)
+	}
+
+	output, failed = run(func(t tst.Test) {
+		t.Expect(1, 2).To(tst.EqualUsing(func(a, b int) bool { return a == b }, 1, "two"))
+	})
+	if !failed || !strings.Contains(output, "string") {
+		t.Fatalf("Expected an error about the type of expected value #2, got output: %s", output)
+	}
+}
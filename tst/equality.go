@@ -0,0 +1,571 @@
+package tst
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"slices"
+	"strings"
+	"unsafe"
+)
+
+// EqualityOption is an option that customizes the deep equality test performed by [EqualWith].
+type EqualityOption func(*equality)
+
+// IgnoreFields returns an option that makes the deep equality test skip struct fields with any of the specified names.
+// A name can be either a plain field name that matches the field in any struct,
+// or a field name qualified with the struct type name, like "Person.Age".
+func IgnoreFields(names ...string) EqualityOption {
+	return func(e *equality) {
+		if e.ignoredFields == nil {
+			e.ignoredFields = make(map[string]bool, len(names))
+		}
+
+		for _, name := range names {
+			e.ignoredFields[name] = true
+		}
+	}
+}
+
+// IgnoreUnexported returns an option that makes the deep equality test skip unexported struct fields.
+func IgnoreUnexported() EqualityOption {
+	return func(e *equality) {
+		e.ignoreUnexported = true
+	}
+}
+
+// IgnoreSliceOrder returns an option that makes the deep equality test treat slices and arrays
+// as equal if they contain the same elements in any order.
+func IgnoreSliceOrder() EqualityOption {
+	return func(e *equality) {
+		e.ignoreOrder = true
+	}
+}
+
+// EquateEmpty returns an option that makes the deep equality test treat nil and empty slices or maps as equal.
+func EquateEmpty() EqualityOption {
+	return func(e *equality) {
+		e.equateEmpty = true
+	}
+}
+
+// FloatTolerance returns an option that makes the deep equality test treat floating point
+// and complex numbers as equal if the absolute difference between them does not exceed the tolerance.
+func FloatTolerance(tolerance float64) EqualityOption {
+	return func(e *equality) {
+		e.tolerance = tolerance
+	}
+}
+
+// IgnoreEqualMethods returns an option that makes the deep equality test compare values structurally
+// even if their type has an Equal method.
+func IgnoreEqualMethods() EqualityOption {
+	return func(e *equality) {
+		e.ignoreEqualMethods = true
+	}
+}
+
+// Comparer returns an option that makes the deep equality test use function f
+// to test values of type T for equality instead of comparing them structurally.
+func Comparer[T any](f func(T, T) bool) EqualityOption {
+	return func(e *equality) {
+		if e.comparers == nil {
+			e.comparers = make(map[reflect.Type]reflect.Value)
+		}
+
+		e.comparers[reflect.TypeFor[T]()] = reflect.ValueOf(f)
+	}
+}
+
+// ---
+
+func newEquality(options ...EqualityOption) *equality {
+	e := &equality{}
+	for _, option := range options {
+		option(e)
+	}
+
+	return e
+}
+
+type equality struct {
+	ignoredFields      map[string]bool
+	ignoreUnexported   bool
+	ignoreOrder        bool
+	equateEmpty        bool
+	ignoreEqualMethods bool
+	tolerance          float64
+	comparers          map[reflect.Type]reflect.Value
+}
+
+func (e *equality) equal(actual, expected any) bool {
+	d := e.differ(1)
+	d.walk(reflect.ValueOf(actual), reflect.ValueOf(expected), "")
+
+	return len(d.differences) == 0
+}
+
+func (e *equality) diff(actual, expected any) []difference {
+	d := e.differ(maxDifferences + 1)
+	d.walk(reflect.ValueOf(actual), reflect.ValueOf(expected), "")
+
+	return d.differences
+}
+
+func (e *equality) differ(limit int) *differ {
+	return &differ{e, nil, limit, make(map[visit]bool)}
+}
+
+// ---
+
+type difference struct {
+	path     string
+	actual   reflect.Value
+	expected reflect.Value
+	redacted bool
+}
+
+// ---
+
+type differences []difference
+
+func (d differences) description(p *printer) string {
+	text := make(textDifferences, len(d))
+	for i, diff := range d {
+		if diff.redacted {
+			text[i] = textDifference{diff.path, redacted, redacted}
+		} else {
+			text[i] = textDifference{diff.path, reflected{diff.actual}.description(p), reflected{diff.expected}.description(p)}
+		}
+	}
+
+	return text.description(p)
+}
+
+// ---
+
+type textDifference struct {
+	path     string
+	actual   string
+	expected string
+}
+
+// ---
+
+type textDifferences []textDifference
+
+func (d textDifferences) description(p *printer) string {
+	var sb strings.Builder
+	sb.WriteString("Differences:")
+
+	for i, diff := range d {
+		if i == maxDifferences {
+			fmt.Fprintf(&sb, "\n%s...", indentSnippet)
+
+			break
+		}
+
+		path := diff.path
+		if path == "" {
+			path = "(root)"
+		}
+
+		sb.WriteRune('\n')
+		sb.WriteString(indent(1, fmt.Sprintf("%s:\n%s\n%s",
+			path,
+			indent(1, "actual   "+p.paint(colorActual, diff.actual)),
+			indent(1, "expected "+p.paint(colorExpected, diff.expected)),
+		)))
+	}
+
+	return sb.String()
+}
+
+// ---
+
+type differ struct {
+	*equality
+	differences []difference
+	limit       int
+	visited     map[visit]bool
+}
+
+func (d *differ) done() bool {
+	return len(d.differences) >= d.limit
+}
+
+func (d *differ) report(path string, actual, expected reflect.Value) {
+	if !d.done() {
+		d.differences = append(d.differences, difference{path, actual, expected, false})
+	}
+}
+
+func (d *differ) same(actual, expected reflect.Value) bool {
+	sub := &differ{d.equality, nil, 1, maps.Clone(d.visited)}
+	sub.walk(actual, expected, "")
+
+	return len(sub.differences) == 0
+}
+
+func (d *differ) walk(actual, expected reflect.Value, path string) {
+	if d.done() {
+		return
+	}
+
+	if !actual.IsValid() || !expected.IsValid() {
+		if actual.IsValid() != expected.IsValid() {
+			d.report(path, actual, expected)
+		}
+
+		return
+	}
+
+	if actual.Type() != expected.Type() {
+		d.report(path, actual, expected)
+
+		return
+	}
+
+	if x, y, ok := exposed(actual, expected); ok {
+		if f, ok := d.comparers[actual.Type()]; ok {
+			if !f.Call([]reflect.Value{x, y})[0].Bool() {
+				d.report(path, actual, expected)
+			}
+
+			return
+		}
+
+		if method, ok := d.equalMethod(x, y); ok {
+			if !method.Call([]reflect.Value{y})[0].Bool() {
+				d.report(path, actual, expected)
+			}
+
+			return
+		}
+	}
+
+	if d.seen(actual, expected) {
+		return
+	}
+
+	switch actual.Kind() {
+	case reflect.Array:
+		d.walkSequence(actual, expected, path)
+
+	case reflect.Slice:
+		if d.equateEmpty && actual.Len() == 0 && expected.Len() == 0 {
+			return
+		}
+
+		if actual.IsNil() != expected.IsNil() {
+			d.report(path, actual, expected)
+
+			return
+		}
+
+		if actual.Len() == expected.Len() && actual.Pointer() == expected.Pointer() {
+			return
+		}
+
+		d.walkSequence(actual, expected, path)
+
+	case reflect.Interface:
+		if actual.IsNil() || expected.IsNil() {
+			if actual.IsNil() != expected.IsNil() {
+				d.report(path, actual, expected)
+			}
+
+			return
+		}
+
+		d.walk(actual.Elem(), expected.Elem(), path)
+
+	case reflect.Pointer:
+		if actual.Pointer() == expected.Pointer() {
+			return
+		}
+
+		if actual.IsNil() || expected.IsNil() {
+			d.report(path, actual, expected)
+
+			return
+		}
+
+		d.walk(actual.Elem(), expected.Elem(), path)
+
+	case reflect.Struct:
+		d.walkStruct(actual, expected, path)
+
+	case reflect.Map:
+		d.walkMap(actual, expected, path)
+
+	case reflect.Func:
+		if !actual.IsNil() || !expected.IsNil() {
+			d.report(path, actual, expected)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		x, y := actual.Float(), expected.Float()
+		if diff := math.Abs(x - y); x != y && (math.IsNaN(diff) || diff > d.tolerance) {
+			d.report(path, actual, expected)
+		}
+
+	case reflect.Complex64, reflect.Complex128:
+		x, y := actual.Complex(), expected.Complex()
+		if diff := cmplx.Abs(x - y); x != y && (math.IsNaN(diff) || diff > d.tolerance) {
+			d.report(path, actual, expected)
+		}
+
+	default:
+		if !equalScalars(actual, expected) {
+			d.report(path, actual, expected)
+		}
+	}
+}
+
+func (d *differ) walkSequence(actual, expected reflect.Value, path string) {
+	if d.ignoreOrder {
+		d.walkUnordered(actual, expected, path)
+
+		return
+	}
+
+	n := min(actual.Len(), expected.Len())
+	for i := range n {
+		d.walk(actual.Index(i), expected.Index(i), fmt.Sprintf("%s[%d]", path, i))
+	}
+
+	for i := n; i < actual.Len(); i++ {
+		d.report(fmt.Sprintf("%s[%d]", path, i), actual.Index(i), reflect.Value{})
+	}
+
+	for i := n; i < expected.Len(); i++ {
+		d.report(fmt.Sprintf("%s[%d]", path, i), reflect.Value{}, expected.Index(i))
+	}
+}
+
+func (d *differ) walkUnordered(actual, expected reflect.Value, path string) {
+	matched := make([]bool, expected.Len())
+
+	for i := range actual.Len() {
+		found := false
+
+		for j := range expected.Len() {
+			if !matched[j] && d.same(actual.Index(i), expected.Index(j)) {
+				matched[j] = true
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			d.report(fmt.Sprintf("%s[%d]", path, i), actual.Index(i), reflect.Value{})
+		}
+	}
+
+	for j := range expected.Len() {
+		if !matched[j] {
+			d.report(fmt.Sprintf("%s[%d]", path, j), reflect.Value{}, expected.Index(j))
+		}
+	}
+}
+
+func (d *differ) walkStruct(actual, expected reflect.Value, path string) {
+	actual, expected = addressable(actual), addressable(expected)
+	t := actual.Type()
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if d.ignoredFields[field.Name] || d.ignoredFields[t.Name()+"."+field.Name] {
+			continue
+		}
+
+		if d.ignoreUnexported && !field.IsExported() {
+			continue
+		}
+
+		if redactedField(field) {
+			if !d.done() && !d.same(actual.Field(i), expected.Field(i)) {
+				d.differences = append(d.differences, difference{path + "." + field.Name, actual.Field(i), expected.Field(i), true})
+			}
+
+			continue
+		}
+
+		d.walk(actual.Field(i), expected.Field(i), path+"."+field.Name)
+	}
+}
+
+func (d *differ) walkMap(actual, expected reflect.Value, path string) {
+	if d.equateEmpty && actual.Len() == 0 && expected.Len() == 0 {
+		return
+	}
+
+	if actual.IsNil() != expected.IsNil() {
+		d.report(path, actual, expected)
+
+		return
+	}
+
+	if actual.Pointer() == expected.Pointer() {
+		return
+	}
+
+	for _, key := range sortedMapKeys(actual) {
+		y := expected.MapIndex(key)
+		if !y.IsValid() {
+			d.report(mapKeyPath(path, key), actual.MapIndex(key), y)
+
+			continue
+		}
+
+		d.walk(actual.MapIndex(key), y, mapKeyPath(path, key))
+	}
+
+	for _, key := range sortedMapKeys(expected) {
+		if !actual.MapIndex(key).IsValid() {
+			d.report(mapKeyPath(path, key), reflect.Value{}, expected.MapIndex(key))
+		}
+	}
+}
+
+func (d *differ) equalMethod(actual, expected reflect.Value) (reflect.Value, bool) {
+	if d.ignoreEqualMethods {
+		return reflect.Value{}, false
+	}
+
+	switch actual.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if actual.IsNil() || expected.IsNil() {
+			return reflect.Value{}, false
+		}
+	}
+
+	method := actual.MethodByName("Equal")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	mt := method.Type()
+	if mt.NumIn() != 1 || mt.In(0) != actual.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return reflect.Value{}, false
+	}
+
+	return method, true
+}
+
+func (d *differ) seen(actual, expected reflect.Value) bool {
+	switch actual.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if actual.IsNil() || expected.IsNil() {
+			return false
+		}
+	default:
+		return false
+	}
+
+	v := visit{actual.Pointer(), expected.Pointer(), actual.Type()}
+	if d.visited[v] {
+		return true
+	}
+
+	d.visited[v] = true
+
+	return false
+}
+
+// ---
+
+// exposed returns the values in a form that can be passed to comparers and Equal methods.
+// Values of unexported struct fields are exposed using their addresses,
+// so nested values like [time.Time] are compared the same way no matter whether the field is exported.
+// Values of unexported fields that are not addressable, like map values, are not exposed.
+func exposed(actual, expected reflect.Value) (reflect.Value, reflect.Value, bool) {
+	x, ok := expose(actual)
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	y, ok := expose(expected)
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	return x, y, true
+}
+
+func expose(v reflect.Value) (reflect.Value, bool) {
+	switch {
+	case v.CanInterface():
+		return v, true
+	case v.CanAddr():
+		//nolint:gosec // the exposed value is only read by comparers and Equal methods
+		return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem(), true
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// addressable returns an addressable copy of the value unless it is addressable already,
+// so that its unexported fields can be exposed.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() || !v.CanInterface() {
+		return v
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	return c
+}
+
+// ---
+
+type visit struct {
+	actual   uintptr
+	expected uintptr
+	typ      reflect.Type
+}
+
+// ---
+
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	return keys
+}
+
+func mapKeyPath(path string, key reflect.Value) string {
+	return fmt.Sprintf("%s[%#v]", path, key)
+}
+
+func equalScalars(actual, expected reflect.Value) bool {
+	switch actual.Kind() {
+	case reflect.Bool:
+		return actual.Bool() == expected.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return actual.Int() == expected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return actual.Uint() == expected.Uint()
+	case reflect.String:
+		return actual.String() == expected.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return actual.Pointer() == expected.Pointer()
+	default:
+		return false
+	}
+}
+
+// ---
+
+var defaultEquality = newEquality()
+
+const maxDifferences = 10
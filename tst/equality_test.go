@@ -0,0 +1,137 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type record struct {
+	ID     int
+	Name   string
+	Tags   []string
+	Scores map[string]float64
+	secret string
+}
+
+func TestEqualWith(t *testing.T) {
+	base := record{1, "a", []string{"x", "y"}, map[string]float64{"q": 1}, "s"}
+
+	tests := []struct {
+		name     string
+		actual   record
+		expected record
+		options  []tst.EqualityOption
+		ok       bool
+	}{
+		{"identical", base, base, nil, true},
+		{"ignore-fields", base, record{2, "b", base.Tags, base.Scores, "s"}, []tst.EqualityOption{tst.IgnoreFields("ID", "record.Name")}, true},
+		{"ignore-fields-other", base, record{2, "a", base.Tags, base.Scores, "s"}, []tst.EqualityOption{tst.IgnoreFields("Name")}, false},
+		{"ignore-unexported", base, record{1, "a", base.Tags, base.Scores, "t"}, []tst.EqualityOption{tst.IgnoreUnexported()}, true},
+		{"ignore-slice-order", base, record{1, "a", []string{"y", "x"}, base.Scores, "s"}, []tst.EqualityOption{tst.IgnoreSliceOrder()}, true},
+		{"slice-order-matters", base, record{1, "a", []string{"y", "x"}, base.Scores, "s"}, nil, false},
+		{"equate-empty", record{}, record{Tags: []string{}, Scores: map[string]float64{}}, []tst.EqualityOption{tst.EquateEmpty()}, true},
+		{"nil-vs-empty", record{}, record{Tags: []string{}}, nil, false},
+		{"float-tolerance", base, record{1, "a", base.Tags, map[string]float64{"q": 1.05}, "s"}, []tst.EqualityOption{tst.FloatTolerance(0.1)}, true},
+		{"float-tolerance-exceeded", base, record{1, "a", base.Tags, map[string]float64{"q": 1.5}, "s"}, []tst.EqualityOption{tst.FloatTolerance(0.1)}, false},
+		{"comparer", base, record{1, "A", base.Tags, base.Scores, "s"}, []tst.EqualityOption{tst.Comparer(strings.EqualFold)}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).ToEqualWith(tc.expected, tc.options...)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected ToEqualWith to pass = %v", tc.ok)
+			}
+		})
+	}
+}
+
+func TestEqualDifferences(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(record{ID: 1, Name: "a"}).ToEqual(record{ID: 1, Name: "b", Tags: []string{"x"}})
+	})
+	if !failed {
+		t.Fatalf("Expected ToEqual to fail")
+	}
+
+	for _, expected := range []string{"Differences:", ".Name:", ".Tags:", `actual   <string>: [1] "a"`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output %q to contain %q", output, expected)
+		}
+	}
+
+	if strings.Contains(output, ".ID:") {
+		t.Fatalf("Expected output %q not to contain equal field .ID", output)
+	}
+}
+
+func TestEqualUsesEqualMethods(t *testing.T) {
+	type event struct {
+		At   time.Time
+		Tags []time.Time
+	}
+
+	now := time.Now()
+	utc := now.UTC()
+
+	_, failed := run(func(t tst.Test) {
+		t.Expect(event{now, []time.Time{now}}).ToEqual(event{utc, []time.Time{utc}})
+	})
+	if failed {
+		t.Fatalf("Expected ToEqual to pass for the same instant in different locations")
+	}
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect(now).ToEqualWith(utc, tst.IgnoreEqualMethods())
+	})
+	if !failed {
+		t.Fatalf("Expected ToEqualWith(IgnoreEqualMethods()) to fail for the same instant in different locations")
+	}
+}
+
+func TestEqualUsesEqualMethodsOfUnexportedFields(t *testing.T) {
+	type event struct {
+		at   time.Time
+		tags []time.Time
+	}
+
+	now := time.Now()
+	wall := now.Round(0)
+
+	_, failed := run(func(t tst.Test) {
+		t.Expect(event{now, []time.Time{now}}).ToEqual(event{wall, []time.Time{wall}})
+	})
+	if failed {
+		t.Fatalf("Expected ToEqual to pass for unexported fields with and without a monotonic clock reading")
+	}
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(event{at: now}).ToEqual(event{at: now.Add(time.Second)})
+	})
+	_, diff, _ := strings.Cut(output, "Differences:")
+	if !failed || !strings.Contains(diff, ".at:\n") || strings.Contains(diff, "loc:") {
+		t.Fatalf("Expected ToEqual to report the unexported field as a whole, got output: %s", output)
+	}
+
+	type box struct {
+		n int
+	}
+
+	type wrapper struct {
+		box box
+	}
+
+	parity := tst.Comparer(func(a, b box) bool { return a.n%2 == b.n%2 })
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect(wrapper{box{1}}).ToEqualWith(wrapper{box{3}}, parity)
+	})
+	if failed {
+		t.Fatalf("Expected ToEqualWith to use the comparer for an unexported field")
+	}
+}
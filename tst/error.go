@@ -1,8 +1,10 @@
 package tst
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // ---
@@ -42,6 +44,59 @@ func (e errUnexpectedAssertionTypeError) Error() string {
 
 // ---
 
+type errUnexpectedNilValueError struct {
+	index     int
+	expected  string
+	assertion bool
+}
+
+func (e errUnexpectedNilValueError) Error() string {
+	what := "value to test"
+	if e.assertion {
+		what = "value in assertion"
+	}
+
+	return fmt.Sprintf("%s #%d is nil but it is expected to have non-nillable type <%s>", what, e.index+1, e.expected)
+}
+
+// ---
+
+type errUnsupportedOperatorError struct {
+	operator string
+}
+
+func (e errUnsupportedOperatorError) Error() string {
+	return fmt.Sprintf("operator %q is not supported", e.operator)
+}
+
+// ---
+
+type errNegativeDurationError struct {
+	duration time.Duration
+}
+
+func (e errNegativeDurationError) Error() string {
+	return fmt.Sprintf("duration is expected to be non-negative but it is %v", e.duration)
+}
+
+// ---
+
+type errTooManyArgumentsError struct {
+	operator string
+	actual   int
+	max      int
+}
+
+func (e errTooManyArgumentsError) Error() string {
+	return fmt.Sprintf("operator %q accepts at most %d optional arguments but got %d", e.operator, e.max, e.actual)
+}
+
+// ---
+
+var errNoAssertionsInComposite = errors.New("expected 1 or more assertion in composite assertion")
+
+// ---
+
 func typeOf[V any](v V) string {
 	if any(v) == nil {
 		return reflect.TypeOf(&v).Elem().String()
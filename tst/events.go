@@ -0,0 +1,96 @@
+package tst
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Events returns a test option that makes each failed expectation of the test log a machine-readable [Event]
+// in addition to the failure message, if enabled.
+// It can also be enabled by the TST_EVENTS environment variable or the `-tst.events` test flag, see [New].
+func Events(enabled bool) Option {
+	return func(c *core) {
+		c.events = enabled
+	}
+}
+
+// Event is a record of a failed expectation.
+// It is logged as a single line starting with [EventPrefix] followed by the JSON representation of the event,
+// so that it can be extracted from the output of `go test -json` using [ParseEvent].
+type Event struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Matcher  string `json:"matcher"`
+	Subject  string `json:"subject,omitempty"`
+	Actual   string `json:"actual"`
+	Expected string `json:"expected"`
+	Diff     string `json:"diff,omitempty"`
+}
+
+// EventPrefix is the prefix of lines holding an [Event].
+const EventPrefix = "tst:event "
+
+// ParseEvent extracts an [Event] from a line of test output.
+// It returns false if the line does not hold an event.
+func ParseEvent(line string) (Event, bool) {
+	_, data, found := strings.Cut(line, EventPrefix)
+	if !found {
+		return Event{}, false
+	}
+
+	var event Event
+
+	err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event)
+	if err != nil {
+		return Event{}, false
+	}
+
+	return event, true
+}
+
+// ---
+
+// failure logs a failure message and an [Event] if events are enabled.
+// Details, if not empty, carry additional information like the differences found by the assertion,
+// they are rendered once and reused without colors for the event.
+func (e Expectation) failure(matcher, what string, actual, expected describable, details string) {
+	e.t.Helper()
+
+	e.log(msg(e.printer, what, actual, expected) + details)
+
+	if !e.t.events {
+		return
+	}
+
+	p := *e.printer
+	p.colors = colorNever
+
+	file, line := e.tag.fileLine()
+	event := Event{
+		File:     file,
+		Line:     line,
+		Matcher:  matcher,
+		Subject:  what,
+		Actual:   p.scrub(actual.description(&p)),
+		Expected: p.scrub(expected.description(&p)),
+		Diff:     p.scrub(unpaint(strings.TrimPrefix(details, "\n"))),
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		e.log(err)
+
+		return
+	}
+
+	e.t.Log(EventPrefix + string(data))
+}
+
+// matcherName returns the name of the exported constructor of the assertion, like `Equal` or `Contain`.
+func matcherName(assertion Assertion) string {
+	if named, ok := assertion.(interface{ matcherName() string }); ok {
+		return named.matcherName()
+	}
+
+	return ""
+}
@@ -0,0 +1,136 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestEvents(t *testing.T) {
+	m := &mockT{}
+
+	tt := tst.New(m, tst.Events(true), tst.Soft(true))
+	tt.Expect(42).ToEqual(43)
+	tt.Expect(1, 2).ToEqual(1, 3)
+
+	var events []tst.Event
+
+	for _, line := range m.logs {
+		if event, ok := tst.ParseEvent(line); ok {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d in output: %s", len(events), m.output())
+	}
+
+	event := events[0]
+	if !strings.HasSuffix(event.File, "/tst/events_test.go") || event.Line != 15 {
+		t.Fatalf("Expected event location to be events_test.go:15, got %s:%d", event.File, event.Line)
+	}
+
+	if event.Matcher != "Equal" || event.Actual != "<int>: 42" || event.Expected != "equal to\n    <int>: 43" {
+		t.Fatalf("Unexpected event %+v", event)
+	}
+
+	if events[1].Subject != "value #2" || events[1].Actual != "<int>: 2" {
+		t.Fatalf("Unexpected event %+v", events[1])
+	}
+
+	output, _ := run(func(t tst.Test) {
+		t.Expect(42).ToEqual(43)
+	})
+
+	if strings.Contains(output, tst.EventPrefix) {
+		t.Fatalf("Expected no events by default, got output: %s", output)
+	}
+}
+
+func TestEventMatcherNames(t *testing.T) {
+	tests := []struct {
+		actual    any
+		assertion tst.Assertion
+		expected  string
+	}{
+		{1, tst.Equal(2), "Equal"},
+		{1, tst.EqualWith(2), "EqualWith"},
+		{1, tst.EqualBy(func(a, b int) bool { return a == b }, 2), "EqualBy"},
+		{1, tst.NotEqual(1), "NotEqual"},
+		{1, tst.Not(tst.Equal(1)), "Not"},
+		{nil, tst.HaveOccurred(), "HaveOccurred"},
+		{1, tst.And(tst.BeZero(), tst.BeNil()), "And"},
+		{1, tst.BeGreaterThan(2), "BeGreaterThan"},
+		{1, tst.LessThan(0), "LessThan"},
+		{5, tst.BeBetween(1, 3), "BeBetween"},
+		{time.Unix(2, 0), tst.BeBefore(time.Unix(1, 0)), "BeBefore"},
+		{time.Unix(1, 0), tst.BeAfter(time.Unix(2, 0)), "BeAfter"},
+		{account{}, tst.Field("ID", tst.Equal(1)), "Field"},
+		{true, tst.BeFalse(), "BeFalse"},
+		{[]int{1}, tst.ContainN(2, tst.Equal(1)), "ContainN"},
+		{[]int{1}, tst.Contain(tst.Equal(2)), "Contain"},
+		{"a", tst.HavePrefix("b"), "HavePrefix"},
+		{[]int{2, 1}, tst.BeSorted(), "BeSorted"},
+		{3, tst.BeNumerically("<", 2), "BeNumerically"},
+		{`{}`, tst.MatchJSONSubset(`{"a":1}`), "MatchJSONSubset"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expected, func(t *testing.T) {
+			m := &mockT{}
+
+			tt := tst.New(m, tst.Events(true), tst.Soft(true))
+			tt.Expect(tc.actual).To(tc.assertion)
+
+			var matchers []string
+
+			for _, line := range m.logs {
+				if event, ok := tst.ParseEvent(line); ok {
+					matchers = append(matchers, event.Matcher)
+				}
+			}
+
+			if len(matchers) != 1 || matchers[0] != tc.expected {
+				t.Fatalf("Expected matcher %q, got %q in output: %s", tc.expected, matchers, m.output())
+			}
+		})
+	}
+}
+
+func TestEventDiffRenderedOnce(t *testing.T) {
+	calls := 0
+	counting := func(a, b int) bool {
+		calls++
+
+		return a == b
+	}
+
+	m := &mockT{}
+
+	tt := tst.New(m, tst.Events(true), tst.Soft(true), tst.WithFormat(tst.Colors(true)))
+	tt.Expect([]int{1}).To(tst.MatchAllElements(func(int) int { return 0 }, map[int]tst.Assertion{
+		0: tst.Not(tst.EqualBy(counting, 1)),
+	}))
+
+	var events []tst.Event
+
+	for _, line := range m.logs {
+		if event, ok := tst.ParseEvent(line); ok {
+			events = append(events, event)
+		}
+	}
+
+	if len(events) != 1 || !strings.Contains(events[0].Diff, "[0] with id 0 is expected to not") {
+		t.Fatalf("Expected an event with the diff, got output: %s", m.output())
+	}
+
+	if strings.Contains(events[0].Diff, "\x1b[") || !strings.Contains(m.output(), "\x1b[") {
+		t.Fatalf("Expected colors in the log line only, got output: %q", m.output())
+	}
+
+	if calls != 2 {
+		t.Fatalf("Expected a call to check and a call to render the diff, got %d calls", calls)
+	}
+}
@@ -11,9 +11,23 @@ import (
 
 // Expectation is an expectation builder that have associated values to be tested against assertions.
 type Expectation struct {
-	t      *core
-	actual []any
-	tag    LineTag
+	t       *core
+	actual  []any
+	tag     LineTag
+	printer *printer
+}
+
+// WithFormat returns a copy of the expectation that renders values in failure messages
+// using the specified options in addition to the ones of the test.
+func (e Expectation) WithFormat(options ...FormatOption) Expectation {
+	p := *e.printer
+	for _, option := range options {
+		option(&p)
+	}
+
+	e.printer = &p
+
+	return e
 }
 
 // To tests that the associated values conform all of the given assertions.
@@ -26,44 +40,49 @@ func (e Expectation) To(assertions ...Assertion) {
 
 	if len(e.actual) != len(assertions) {
 		if len(assertions) != 1 || len(e.actual) <= 1 {
-			e.log(msg("number of values to test", value{len(e.actual)}, expDesc("be", len(assertions))))
+			e.failure("To", "number of values to test", value{len(e.actual)}, expDesc(e.printer, "be", len(assertions)), "")
 			e.fail()
 		}
 
-		assertion = func(int) Assertion {
-			return assertions[0]
+		assertion = func(i int) Assertion {
+			return assertions[0].at(i)
 		}
 	}
 
-	fail := func(i int, assertion Assertion) {
+	failed := false
+	fail := func(i int, assertion Assertion, o outcome) {
 		e.t.Helper()
 
+		failed = true
+
 		what := ""
 		if len(e.actual) != 1 {
 			what = fmt.Sprintf("value #%d", i+1)
 		}
 
-		e.log(msg(what, value{e.actual[i]}, assertion))
+		e.failure(matcherName(assertion), what, value{e.actual[i]}, assertion, explanation(e.printer, o))
 		e.t.Fail()
 	}
 
 	if len(assertions) == 1 && len(e.actual) > 1 {
-		ok := e.check(assertions[0], e.actual)
+		outcomes := e.evaluate(assertions[0], e.actual)
 		for i := range e.actual {
-			if !ok[i] {
-				fail(i, assertions[0].at(i))
+			if !outcomes[i].passed {
+				fail(i, assertion(i), outcomes[i])
 			}
 		}
 	} else {
 		for i := range e.actual {
-			if !e.check(assertion(i), []any{e.actual[i]})[0] {
-				fail(i, assertion(i))
+			if o := e.evaluate(assertion(i), e.actual[i:i+1])[0]; !o.passed {
+				fail(i, assertion(i), o)
 			}
 		}
 	}
 
-	if e.t.Failed() {
-		e.fail()
+	e.traceTo(assertion, failed)
+
+	if failed {
+		e.reject()
 	}
 }
 
@@ -96,6 +115,14 @@ func (e Expectation) ToNotEqual(expected ...any) {
 	e.To(NotEqual(expected...))
 }
 
+// ToEqualWith tests that the associated values are equal to the specified expected value
+// using the deep equality test customized with the specified options.
+func (e Expectation) ToEqualWith(expected any, options ...EqualityOption) {
+	e.t.Helper()
+
+	e.To(EqualWith(expected, options...))
+}
+
 // ToBeTrue tests that all of the associated values are boolean values and are true.
 func (e Expectation) ToBeTrue() {
 	e.t.Helper()
@@ -119,7 +146,7 @@ func (e Expectation) ToSucceed() SuccessExpectation {
 	e.t.Helper()
 
 	if len(e.actual) == 0 {
-		e.log(msg("number of values to test", value{len(e.actual)}, expDescText("be", "non-zero")))
+		e.failure("ToSucceed", "number of values to test", value{len(e.actual)}, expDescText("be", "non-zero"), "")
 		e.fail()
 	}
 
@@ -130,7 +157,7 @@ func (e Expectation) ToSucceed() SuccessExpectation {
 
 	actual, ok := last.(error)
 	if !ok {
-		e.log(msg("last value to test", value{last}, expDescText("be", "an error")))
+		e.failure("ToSucceed", "last value to test", value{last}, expDescText("be", "an error"), "")
 		e.fail()
 	}
 
@@ -138,7 +165,7 @@ func (e Expectation) ToSucceed() SuccessExpectation {
 		return SuccessExpectation{e}
 	}
 
-	e.log(msg("error", value{actual}, expDesc("be", nil)))
+	e.failure("ToSucceed", "error", value{actual}, expDesc(e.printer, "be", nil), "")
 	e.fail()
 
 	return SuccessExpectation{}
@@ -150,7 +177,7 @@ func (e Expectation) ToSucceed() SuccessExpectation {
 // All other values are ignored in this expectation.
 func (e Expectation) ToFail() {
 	if len(e.actual) == 0 {
-		e.log(msg("number of values to test", value{len(e.actual)}, expDescText("be", "non-zero")))
+		e.failure("ToFail", "number of values to test", value{len(e.actual)}, expDescText("be", "non-zero"), "")
 		e.fail()
 	}
 
@@ -158,7 +185,7 @@ func (e Expectation) ToFail() {
 	if last != nil {
 		_, ok := last.(error)
 		if !ok {
-			e.log(msg("last value to test", value{last}, expDescText("be", "an error")))
+			e.failure("ToFail", "last value to test", value{last}, expDescText("be", "an error"), "")
 			e.fail()
 		}
 
@@ -166,8 +193,8 @@ func (e Expectation) ToFail() {
 	}
 
 	e.t.Helper()
-	e.log(msg("error", value{last}, expDescText("be", "non-nil error")))
-	e.fail()
+	e.failure("ToFail", "error", value{last}, expDescText("be", "non-nil error"), "")
+	e.reject()
 }
 
 // ToFailWith builds expectation for an error value that is expected be the last in the list of values
@@ -178,7 +205,7 @@ func (e Expectation) ToFailWith(err error) {
 	e.t.Helper()
 
 	if len(e.actual) == 0 {
-		e.log(msg("number of values to test", value{len(e.actual)}, expDescText("be", "non-zero")))
+		e.failure("ToFailWith", "number of values to test", value{len(e.actual)}, expDescText("be", "non-zero"), "")
 		e.fail()
 	}
 
@@ -189,7 +216,7 @@ func (e Expectation) ToFailWith(err error) {
 
 	actual, ok := last.(error)
 	if !ok {
-		e.log(msg("last value to test", value{last}, expDescText("be", "an error")))
+		e.failure("ToFailWith", "last value to test", value{last}, expDescText("be", "an error"), "")
 		e.fail()
 	}
 
@@ -198,18 +225,19 @@ func (e Expectation) ToFailWith(err error) {
 	}
 
 	e.t.Helper()
-	e.log(msg("error", value{actual}, expDesc("be like", err)))
-	e.fail()
+	e.failure("ToFailWith", "error", value{actual}, expDesc(e.printer, "be like", err), "")
+	e.reject()
 }
 
-func (e Expectation) check(assertion Assertion, actual []any) []bool {
+// evaluate tests the values against the assertion and returns the result trees to render failures from.
+func (e Expectation) evaluate(assertion Assertion, actual []any) []outcome {
 	e.t.Helper()
 
-	ok, err := assertion.check(actual)
+	outcomes, err := evaluate(assertion, actual)
 	if err != nil {
 		var ee errNumberOfValuesToTestDiffersError
 		if errors.As(err, &ee) {
-			e.log(msg("number of values to test", value{ee.actual}, expDesc("be", ee.expected)))
+			e.failure(matcherName(assertion), "number of values to test", value{ee.actual}, expDesc(e.printer, "be", ee.expected), "")
 		} else {
 			e.log(err)
 		}
@@ -217,19 +245,30 @@ func (e Expectation) check(assertion Assertion, actual []any) []bool {
 		e.fail()
 	}
 
-	return ok
+	return outcomes
 }
 
 func (e Expectation) log(args ...any) {
 	e.t.Helper()
-	e.t.Log(args...)
+	e.t.Log(e.printer.scrub(strings.TrimSuffix(fmt.Sprintln(args...), "\n")))
 }
 
+// fail marks the test as failed and stops it.
 func (e Expectation) fail() {
 	e.t.addLineTags(e.tag)
 	e.t.FailNow()
 }
 
+// reject marks the test as failed and stops it unless the test is in soft mode.
+func (e Expectation) reject() {
+	if !e.t.soft {
+		e.fail()
+	}
+
+	e.t.addLineTags(e.tag)
+	e.t.Fail()
+}
+
 // ---
 
 // SuccessExpectation is an expectation build that can be used
@@ -240,13 +279,13 @@ type SuccessExpectation struct {
 
 // AndResult returns an expectation builder for the associated values except for the last one.
 func (e SuccessExpectation) AndResult() Expectation {
-	return Expectation{e.e.t, e.e.actual[:len(e.e.actual)-1], e.e.tag}
+	return Expectation{e.e.t, e.e.actual[:len(e.e.actual)-1], e.e.tag, e.e.printer}
 }
 
 // ---
 
-func expDesc(what string, expected any) expTextDesc {
-	return expDescText(what, value{expected}.description())
+func expDesc(p *printer, what string, expected any) expTextDesc {
+	return expDescText(what, value{expected}.description(p))
 }
 
 func expDescText(what, text string) expTextDesc {
@@ -260,14 +299,25 @@ type expTextDesc struct {
 	text string
 }
 
-func (e expTextDesc) description() string {
+func (e expTextDesc) description(*printer) string {
 	return fmt.Sprintf("%s\n%s", e.what, indent(1, e.text))
 }
 
 // ---
 
-func msg(what string, actual, expected describable) string {
-	return fmt.Sprintf("\nExpected %s\n%s\nto %s", what, indent(1, actual.description()), expected.description())
+func msg(p *printer, what string, actual, expected describable) string {
+	return fmt.Sprintf("\nExpected %s\n%s\nto %s", what,
+		indent(1, p.paint(colorActual, actual.description(p))),
+		p.paint(colorExpected, expected.description(p)),
+	)
+}
+
+func explanation(p *printer, o outcome) string {
+	if text := o.body(p); text != "" {
+		return "\n" + text
+	}
+
+	return ""
 }
 
 // ---
@@ -294,7 +344,11 @@ type value struct {
 	v any
 }
 
-func (v value) description() string {
+func (v value) description(p *printer) string {
+	if text, ok := p.custom(reflect.ValueOf(v.v)); ok {
+		return fmt.Sprintf("<%T>: %s", v.v, text)
+	}
+
 	comment := ""
 	length := -1
 
@@ -302,11 +356,20 @@ func (v value) description() string {
 	case nil:
 		return "<nil>"
 	case []byte:
-		comment = fmt.Sprintf(" | %q", vv)
+		if len(vv) <= p.hexDumpThreshold {
+			comment = " | " + p.quote(string(vv))
+		}
+
 		length = len(vv)
 	case fmt.Stringer:
 		s := vv.String()
-		comment = fmt.Sprintf(" | [%d] %s", len(s), s)
+		n := len(s)
+
+		if cut, ok := p.truncate(s); ok {
+			s = cut + "…"
+		}
+
+		comment = fmt.Sprintf(" | [%d] %s", n, s)
 	default:
 		rv := reflect.ValueOf(vv)
 		switch rv.Kind() {
@@ -320,21 +383,39 @@ func (v value) description() string {
 		ls = fmt.Sprintf("[%d] ", length)
 	}
 
-	return fmt.Sprintf("<%T>: %s%#v%s", v.v, ls, v.v, comment)
+	return fmt.Sprintf("<%T>: %s%s%s", v.v, ls, p.print(v.v), comment)
+}
+
+// ---
+
+type reflected struct {
+	v reflect.Value
+}
+
+func (v reflected) description(p *printer) string {
+	if !v.v.IsValid() {
+		return "<missing>"
+	}
+
+	if x, ok := expose(v.v); ok {
+		return value{x.Interface()}.description(p)
+	}
+
+	return fmt.Sprintf("<%s>: %s", v.v.Type(), p.printValue(v.v))
 }
 
 // ---
 
 type values []any
 
-func (v values) description() string {
+func (v values) description(p *printer) string {
 	if len(v) == 1 {
-		return value{v[0]}.description()
+		return value{v[0]}.description(p)
 	}
 
 	var sb strings.Builder
 	for i := range v {
-		fmt.Fprintf(&sb, "[#%d] %s\n", i+1, value{v[i]}.description())
+		fmt.Fprintf(&sb, "[#%d] %s\n", i+1, value{v[i]}.description(p))
 	}
 
 	return strings.TrimRight(sb.String(), "\n")
@@ -343,7 +424,7 @@ func (v values) description() string {
 // ---
 
 type describable interface {
-	description() string
+	description(p *printer) string
 }
 
 // ---
@@ -0,0 +1,334 @@
+package tst
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldPath is a parsed path to a value nested in a struct, like `Spec.Containers[0].Labels["app"]` or `Status().Phase`.
+type fieldPath []fieldStep
+
+func parseFieldPath(path string) (fieldPath, error) {
+	var steps fieldPath
+
+	fail := func(reason string) (fieldPath, error) {
+		return nil, fmt.Errorf("invalid field path %q: %s", path, reason)
+	}
+
+	rest := path
+	expectName := true
+
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+
+			token := ""
+			if len(rest) > 1 && rest[1] == '"' {
+				quoted, err := strconv.QuotedPrefix(rest[1:])
+				if err != nil {
+					return fail("invalid quoted map key")
+				}
+
+				token = quoted
+				end = 1 + len(quoted)
+			} else if end != -1 {
+				token = rest[1:end]
+			}
+
+			if end == -1 || end >= len(rest) || rest[end] != ']' || token == "" {
+				return fail("unterminated or empty brackets")
+			}
+
+			step := fieldStep{kind: stepIndex, text: rest[:end+1]}
+
+			if token[0] == '"' {
+				step.kind = stepKey
+				step.name, _ = strconv.Unquote(token)
+			} else {
+				index, err := strconv.Atoi(token)
+				if err != nil {
+					return fail(fmt.Sprintf("invalid index %q", token))
+				}
+
+				step.index = index
+			}
+
+			steps = append(steps, step)
+			rest = rest[end+1:]
+			expectName = false
+
+		case rest[0] == '.' && !expectName && len(steps) != 0:
+			rest = rest[1:]
+			expectName = true
+
+		case expectName:
+			end := strings.IndexAny(rest, ".[(")
+			if end == -1 {
+				end = len(rest)
+			}
+
+			name := rest[:end]
+			if !isIdentifier(name) {
+				return fail(fmt.Sprintf("invalid name %q", name))
+			}
+
+			rest = rest[end:]
+
+			step := fieldStep{kind: stepField, name: name, text: name}
+			if strings.HasPrefix(rest, "()") {
+				step.kind = stepMethod
+				step.text += "()"
+				rest = rest[2:]
+			}
+
+			if len(steps) != 0 {
+				step.text = "." + step.text
+			}
+
+			steps = append(steps, step)
+			expectName = false
+
+		default:
+			return fail(fmt.Sprintf("unexpected %q", rest))
+		}
+	}
+
+	if expectName {
+		return fail("missing name")
+	}
+
+	return steps, nil
+}
+
+func (p fieldPath) String() string {
+	var sb strings.Builder
+	for _, step := range p {
+		sb.WriteString(step.text)
+	}
+
+	return sb.String()
+}
+
+// navigate resolves the path against v.
+// If the path cannot be resolved, it returns the part of the path that failed to resolve and the reason.
+func (p fieldPath) navigate(v reflect.Value) (reflect.Value, *fieldPathFailure) {
+	for i, step := range p {
+		var reason string
+
+		v, reason = step.apply(v)
+		if reason != "" {
+			return reflect.Value{}, &fieldPathFailure{p[:i+1].String(), reason}
+		}
+	}
+
+	if !v.CanInterface() {
+		return reflect.Value{}, &fieldPathFailure{p.String(), "value is not accessible because it is unexported"}
+	}
+
+	return v, nil
+}
+
+// redacts reports whether resolving the path against v goes through a struct field tagged with `tst:"redact"`.
+func (p fieldPath) redacts(v reflect.Value) bool {
+	for _, step := range p {
+		if step.kind == stepField {
+			s := v
+			for (s.Kind() == reflect.Pointer || s.Kind() == reflect.Interface) && !s.IsNil() {
+				s = s.Elem()
+			}
+
+			if s.Kind() == reflect.Struct {
+				if field, ok := s.Type().FieldByName(step.name); ok && redactedField(field) {
+					return true
+				}
+			}
+		}
+
+		var reason string
+
+		v, reason = step.apply(v)
+		if reason != "" {
+			return false
+		}
+	}
+
+	return false
+}
+
+// ---
+
+type fieldStep struct {
+	kind  stepKind
+	name  string
+	index int
+	text  string
+}
+
+func (s fieldStep) apply(v reflect.Value) (reflect.Value, string) {
+	if s.kind == stepMethod {
+		return s.call(v)
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Sprintf("cannot access %s of nil <%s>", s.what(), v.Type())
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return reflect.Value{}, fmt.Sprintf("cannot access %s of nil", s.what())
+	}
+
+	switch s.kind {
+	case stepField:
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Sprintf("cannot access %s of non-struct <%s>", s.what(), v.Type())
+		}
+
+		field := v.FieldByName(s.name)
+		if !field.IsValid() {
+			return reflect.Value{}, fmt.Sprintf("<%s> has no %s", v.Type(), s.what())
+		}
+
+		return field, ""
+
+	case stepIndex:
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.String:
+			if s.index < 0 || s.index >= v.Len() {
+				return reflect.Value{}, fmt.Sprintf("index %d is out of range of <%s> with length %d", s.index, v.Type(), v.Len())
+			}
+
+			return v.Index(s.index), ""
+		case reflect.Map:
+			return s.lookup(v, reflect.ValueOf(s.index))
+		default:
+			return reflect.Value{}, fmt.Sprintf("cannot index non-sequence <%s>", v.Type())
+		}
+
+	default:
+		if v.Kind() != reflect.Map {
+			return reflect.Value{}, fmt.Sprintf("cannot access %s of non-map <%s>", s.what(), v.Type())
+		}
+
+		return s.lookup(v, reflect.ValueOf(s.name))
+	}
+}
+
+func (s fieldStep) lookup(m, key reflect.Value) (reflect.Value, string) {
+	switch m.Type().Key().Kind() {
+	case reflect.String:
+		if s.kind != stepKey {
+			return reflect.Value{}, fmt.Sprintf("cannot use %s as key of <%s>", s.what(), m.Type())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if s.kind != stepIndex {
+			return reflect.Value{}, fmt.Sprintf("cannot use %s as key of <%s>", s.what(), m.Type())
+		}
+	default:
+		return reflect.Value{}, fmt.Sprintf("cannot use %s as key of <%s>", s.what(), m.Type())
+	}
+
+	value := m.MapIndex(key.Convert(m.Type().Key()))
+	if !value.IsValid() {
+		return reflect.Value{}, fmt.Sprintf("<%s> has no %s", m.Type(), s.what())
+	}
+
+	return value, ""
+}
+
+func (s fieldStep) call(v reflect.Value) (reflect.Value, string) {
+	for {
+		switch {
+		case !v.IsValid():
+			return reflect.Value{}, fmt.Sprintf("cannot call %s of nil", s.what())
+		case v.Kind() == reflect.Interface && v.IsNil():
+			return reflect.Value{}, fmt.Sprintf("cannot call %s of nil <%s>", s.what(), v.Type())
+		case v.Kind() == reflect.Interface:
+			v = v.Elem()
+
+			continue
+		}
+
+		method := v.MethodByName(s.name)
+		if !method.IsValid() && v.CanAddr() {
+			method = v.Addr().MethodByName(s.name)
+		}
+
+		if method.IsValid() {
+			switch {
+			case method.Type().NumIn() != 0 || method.Type().NumOut() != 1:
+				return reflect.Value{}, fmt.Sprintf("%s of <%s> must have no arguments and a single result", s.what(), v.Type())
+			case v.Kind() == reflect.Pointer && v.IsNil():
+				return reflect.Value{}, fmt.Sprintf("cannot call %s of nil <%s>", s.what(), v.Type())
+			case !v.CanInterface():
+				return reflect.Value{}, fmt.Sprintf("cannot call %s of unexported value", s.what())
+			}
+
+			return method.Call(nil)[0], ""
+		}
+
+		if v.Kind() != reflect.Pointer || v.IsNil() {
+			return reflect.Value{}, fmt.Sprintf("<%s> has no %s", v.Type(), s.what())
+		}
+
+		v = v.Elem()
+	}
+}
+
+func (s fieldStep) what() string {
+	switch s.kind {
+	case stepField:
+		return fmt.Sprintf("field %q", s.name)
+	case stepMethod:
+		return fmt.Sprintf("method %q", s.name)
+	case stepIndex:
+		return fmt.Sprintf("index %d", s.index)
+	default:
+		return fmt.Sprintf("key %q", s.name)
+	}
+}
+
+// ---
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepMethod
+	stepIndex
+	stepKey
+)
+
+// ---
+
+// fieldPathFailure describes the part of a field path that failed to resolve and the reason.
+type fieldPathFailure struct {
+	path   string
+	reason string
+}
+
+// ---
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i != 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
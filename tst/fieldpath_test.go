@@ -0,0 +1,72 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type podContainer struct {
+	Image string
+}
+
+type podSpec struct {
+	Containers []podContainer
+}
+
+type pod struct {
+	Spec   *podSpec
+	Labels map[string]string
+	Ports  map[int]string
+}
+
+func (p pod) Phase() string {
+	return "Running"
+}
+
+func (p *pod) Restarts() int {
+	return 3
+}
+
+func TestHaveFieldPath(t *testing.T) {
+	p := &pod{
+		Spec:   &podSpec{[]podContainer{{"nginx"}}},
+		Labels: map[string]string{"app": "web"},
+		Ports:  map[int]string{80: "http"},
+	}
+
+	tests := []struct {
+		path     string
+		expected any
+		output   string
+	}{
+		{"Spec.Containers[0].Image", "nginx", ""},
+		{`Labels["app"]`, "web", ""},
+		{"Ports[80]", "http", ""},
+		{"Phase()", "Running", ""},
+		{"Restarts()", 3, ""},
+		{"Spec.Containers[1].Image", "nginx", "Cannot resolve field Spec.Containers[1]: index 1 is out of range"},
+		{`Labels["env"]`, "prod", `Cannot resolve field Labels["env"]: <map[string]string> has no key "env"`},
+		{"Spec.Missing", "x", `Cannot resolve field Spec.Missing: <tst_test.podSpec> has no field "Missing"`},
+		{"Spec..Image", "x", `invalid field path "Spec..Image"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(p).To(tst.HaveField(tc.path, tst.Equal(tc.expected)))
+			})
+			if failed != (tc.output != "") || !strings.Contains(output, tc.output) {
+				t.Fatalf("Expected output to contain %q, got failed=%v and output: %s", tc.output, failed, output)
+			}
+		})
+	}
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(pod{}).To(tst.HaveField("Spec.Containers", tst.HaveLen(0)))
+	})
+	if !failed || !strings.Contains(output, `cannot access field "Containers" of nil <*tst_test.podSpec>`) {
+		t.Fatalf("Expected nil pointer failure, got failed=%v and output: %s", failed, output)
+	}
+}
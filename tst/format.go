@@ -0,0 +1,512 @@
+package tst
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// SetDefaultFormat changes the default options used to render values in failure messages.
+// It is not safe for concurrent use and is supposed to be called from TestMain or an init function.
+func SetDefaultFormat(options ...FormatOption) {
+	p := *defaultPrinter
+	for _, option := range options {
+		option(&p)
+	}
+
+	defaultPrinter = &p
+}
+
+// RegisterFormatter registers the function f to render values of type T in failure messages
+// instead of the default rendering for all tests.
+// If T is an interface type, f is used for all values implementing it.
+// A formatter registered for the exact type of the value is preferred,
+// otherwise the first registered formatter for an interface type implemented by the value is used.
+// Registering a formatter for the same type again replaces the previous one keeping its place in the order.
+//
+// Formatters registered for a specific test using [WithFormatter] take precedence over the ones registered using this function,
+// which in turn take precedence over the [Formatter] interface implemented by the value.
+func RegisterFormatter[T any](f func(T) string) {
+	formatters.Lock()
+	defer formatters.Unlock()
+
+	formatters.list = formatters.list.with(reflect.TypeFor[T](), formatterOf(f))
+}
+
+// WithFormatter returns a test option that makes the test use the function f
+// to render values of type T in failure messages instead of the default rendering.
+// Formatters registered for the test are chosen the same way as the ones registered using [RegisterFormatter],
+// and they override all of the latter.
+func WithFormatter[T any](f func(T) string) Option {
+	return func(c *core) {
+		p := *c.printer
+		p.formatters = p.formatters.with(reflect.TypeFor[T](), formatterOf(f))
+		c.printer = &p
+	}
+}
+
+// WithFormat returns a test option that changes the way values are rendered in failure messages of the test.
+func WithFormat(options ...FormatOption) Option {
+	return func(c *core) {
+		p := *c.printer
+		for _, option := range options {
+			option(&p)
+		}
+
+		c.printer = &p
+	}
+}
+
+// MaxDepth returns a format option that limits the nesting depth of rendered structs, slices, arrays and maps.
+// Values nested deeper are rendered as `{...}`.
+func MaxDepth(n int) FormatOption {
+	return func(p *printer) {
+		p.maxDepth = n
+	}
+}
+
+// MaxLength returns a format option that limits the number of rendered elements of slices, arrays and maps.
+// If there are more elements, only the first and the last ones are rendered
+// with the number of elided elements in between, like `… 99,980 more …`.
+func MaxLength(n int) FormatOption {
+	return func(p *printer) {
+		p.maxLength = max(n, 1)
+	}
+}
+
+// MaxStringLength returns a format option that limits the number of rendered bytes of strings.
+// Longer strings are cut and followed by their total length in bytes.
+func MaxStringLength(n int) FormatOption {
+	return func(p *printer) {
+		p.maxStringLength = max(n, 1)
+	}
+}
+
+// ---
+
+// FormatOption is an option that changes the way values are rendered in failure messages.
+type FormatOption func(*printer)
+
+// ---
+
+// Formatter is an interface that can be implemented by a type
+// to provide a custom rendering of its values in failure messages.
+type Formatter interface {
+	TstFormat() string
+}
+
+// ---
+
+// printer renders values in failure messages.
+//
+// Values are rendered similarly to Go syntax, but pointers are dereferenced, map keys are sorted,
+// and long composite values are split into multiple indented lines.
+// Cyclic references are rendered as `<cycle>`, and struct fields tagged with `tst:"redact"`
+// and values of type [Secret], including unexported ones, are rendered as `<redacted>`.
+// Byte slices and arrays longer than the hex dump threshold are rendered as a hex dump.
+// Values having a custom formatter are rendered using it,
+// and values implementing [fmt.GoStringer], like [time.Time], are rendered using the GoString method.
+type printer struct {
+	maxDepth         int
+	maxLength        int
+	maxStringLength  int
+	hexDumpThreshold int
+	colors           colorMode
+	fullPath         bool
+	formatters       formatterList
+	scrubbers        []*regexp.Regexp
+}
+
+func (p *printer) print(v any) string {
+	return p.printValue(reflect.ValueOf(v))
+}
+
+func (p *printer) printValue(v reflect.Value) string {
+	s := printing{p, make(map[visitedRef]bool)}
+
+	return s.format(v, 0, true)
+}
+
+// quote renders the string as a quoted Go string literal cutting it if it is too long.
+func (p *printer) quote(str string) string {
+	cut, ok := p.truncate(str)
+	if !ok {
+		return strconv.Quote(str)
+	}
+
+	return fmt.Sprintf("%s… (%s bytes)", strconv.Quote(cut), groupDigits(len(str)))
+}
+
+// truncate cuts the string at a rune boundary if it is longer than the limit and reports whether it was cut.
+func (p *printer) truncate(str string) (string, bool) {
+	if len(str) <= p.maxStringLength {
+		return str, false
+	}
+
+	n := p.maxStringLength
+	for n > 0 && !utf8.RuneStart(str[n]) {
+		n--
+	}
+
+	return str[:n], true
+}
+
+// custom renders the value using a custom formatter if there is any for its type.
+// Values of type [Secret] are redacted even if they cannot be accessed, like values of unexported fields.
+func (p *printer) custom(v reflect.Value) (string, bool) {
+	if v.IsValid() && v.Type() == secretType {
+		return redacted, true
+	}
+
+	if !v.IsValid() || !v.CanInterface() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return "", false
+	}
+
+	if f, ok := p.formatters.lookup(v.Type()); ok {
+		return f(v), true
+	}
+
+	formatters.RLock()
+	list := formatters.list
+	formatters.RUnlock()
+
+	if f, ok := list.lookup(v.Type()); ok {
+		return f(v), true
+	}
+
+	if formatter, ok := v.Interface().(Formatter); ok {
+		return formatter.TstFormat(), true
+	}
+
+	return "", false
+}
+
+// ---
+
+// valueFormatter renders a value in a custom way.
+type valueFormatter func(reflect.Value) string
+
+// formatterList is a list of custom formatters in the order of their registration.
+// It is never modified in place, so it can be shared by copies of a printer.
+type formatterList []typeFormatter
+
+type typeFormatter struct {
+	t reflect.Type
+	f valueFormatter
+}
+
+// with returns a copy of the list with the formatter f for type t replacing the one registered for the same type
+// or appended to the end.
+func (l formatterList) with(t reflect.Type, f valueFormatter) formatterList {
+	result := slices.Clone(l)
+
+	for i := range result {
+		if result[i].t == t {
+			result[i].f = f
+
+			return result
+		}
+	}
+
+	return append(result, typeFormatter{t, f})
+}
+
+// lookup returns the formatter for the exact type t if there is one,
+// or the first formatter for an interface type implemented by t.
+func (l formatterList) lookup(t reflect.Type) (valueFormatter, bool) {
+	for _, tf := range l {
+		if tf.t == t {
+			return tf.f, true
+		}
+	}
+
+	for _, tf := range l {
+		if tf.t.Kind() == reflect.Interface && t.Implements(tf.t) {
+			return tf.f, true
+		}
+	}
+
+	return nil, false
+}
+
+func formatterOf[T any](f func(T) string) valueFormatter {
+	return func(v reflect.Value) string {
+		//nolint:forcetypeassert // formatters are looked up by the value type
+		return f(v.Interface().(T))
+	}
+}
+
+// ---
+
+// printing is a state of rendering a single value.
+type printing struct {
+	*printer
+	visited map[visitedRef]bool
+}
+
+func (s printing) format(v reflect.Value, depth int, typed bool) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+
+	if text, ok := s.custom(v); ok {
+		return text
+	}
+
+	if v.CanInterface() && v.Type().Implements(goStringerType) && !(v.Kind() == reflect.Pointer && v.IsNil()) {
+		//nolint:forcetypeassert // the type is checked above
+		return v.Interface().(fmt.GoStringer).GoString()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())
+	case reflect.String:
+		return s.quote(v.String())
+	case reflect.Interface:
+		if v.IsNil() {
+			return "nil"
+		}
+
+		return s.format(v.Elem(), depth, true)
+	case reflect.Pointer:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", v.Type())
+		}
+
+		return s.reference(v, func() string {
+			if !typed {
+				return s.format(v.Elem(), depth, false)
+			}
+
+			return "&" + s.format(v.Elem(), depth, true)
+		})
+	case reflect.Struct:
+		return s.structure(v, depth, typed)
+	case reflect.Slice:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", v.Type())
+		}
+
+		return s.reference(v, func() string {
+			return s.sequence(v, depth, typed)
+		})
+	case reflect.Array:
+		return s.sequence(v, depth, typed)
+	case reflect.Map:
+		if v.IsNil() {
+			return fmt.Sprintf("%s(nil)", v.Type())
+		}
+
+		return s.reference(v, func() string {
+			return s.mapping(v, depth, typed)
+		})
+	case reflect.Func:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil)", v.Type())
+		}
+
+		return funcName(v)
+	default:
+		return fmt.Sprintf("(%s)(%#x)", v.Type(), v.Pointer())
+	}
+}
+
+// reference renders a pointer, a slice or a map using f unless it is already being rendered at an upper level.
+func (s printing) reference(v reflect.Value, f func() string) string {
+	ref := visitedRef{v.Pointer(), v.Type()}
+	if s.visited[ref] {
+		return "<cycle>"
+	}
+
+	s.visited[ref] = true
+	defer delete(s.visited, ref)
+
+	return f()
+}
+
+func (s printing) structure(v reflect.Value, depth int, typed bool) string {
+	if depth >= s.maxDepth && v.NumField() != 0 {
+		return s.prefix(v, typed) + "{...}"
+	}
+
+	items := make([]string, v.NumField())
+	for i := range items {
+		field := v.Type().Field(i)
+		if redactedField(field) {
+			items[i] = field.Name + ": " + redacted
+		} else {
+			items[i] = field.Name + ": " + s.format(v.Field(i), depth+1, true)
+		}
+	}
+
+	return s.composite(s.prefix(v, typed), items)
+}
+
+func (s printing) sequence(v reflect.Value, depth int, typed bool) string {
+	if depth >= s.maxDepth && v.Len() != 0 {
+		return s.prefix(v, typed) + "{...}"
+	}
+
+	if data, ok := bytesOf(v); ok && len(data) > s.hexDumpThreshold {
+		return s.prefix(v, typed) + "{\n" + indent(1, strings.Join(s.hexDump(data), "\n")) + "\n}"
+	}
+
+	elementsTyped := !elidable(v.Type().Elem())
+
+	items := s.elide(v.Len(), func(i int) string {
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("0x%02x", v.Index(i).Uint())
+		}
+
+		return s.format(v.Index(i), depth+1, elementsTyped)
+	})
+
+	return s.composite(s.prefix(v, typed), items)
+}
+
+func (s printing) mapping(v reflect.Value, depth int, typed bool) string {
+	if depth >= s.maxDepth && v.Len() != 0 {
+		return s.prefix(v, typed) + "{...}"
+	}
+
+	keys := sortedMapKeys(v)
+	keysTyped := !elidable(v.Type().Key())
+	valuesTyped := !elidable(v.Type().Elem())
+
+	items := s.elide(len(keys), func(i int) string {
+		return s.format(keys[i], depth+1, keysTyped) + ": " + s.format(v.MapIndex(keys[i]), depth+1, valuesTyped)
+	})
+
+	return s.composite(s.prefix(v, typed), items)
+}
+
+// elide renders n items using the item function
+// replacing the items in the middle with the number of elided items in case there are more than the limit.
+func (p *printer) elide(n int, item func(int) string) []string {
+	head, tail := n, 0
+	if n > p.maxLength {
+		head, tail = (p.maxLength+1)/2, p.maxLength/2
+	}
+
+	items := make([]string, 0, head+tail+1)
+	for i := range head {
+		items = append(items, item(i))
+	}
+
+	if tail != 0 || head != n {
+		items = append(items, fmt.Sprintf("… %s more …", groupDigits(n-head-tail)))
+	}
+
+	for i := n - tail; i < n; i++ {
+		items = append(items, item(i))
+	}
+
+	return items
+}
+
+// composite renders a composite value with the specified items on a single line if it is short enough
+// or on multiple lines otherwise.
+func (s printing) composite(prefix string, items []string) string {
+	line := prefix + "{" + strings.Join(items, ", ") + "}"
+	if len(line) <= maxInlineWidth && !strings.Contains(line, "\n") {
+		return line
+	}
+
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	sb.WriteString("{\n")
+
+	for _, item := range items {
+		sb.WriteString(indent(1, item))
+		sb.WriteString(",\n")
+	}
+
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+func (s printing) prefix(v reflect.Value, typed bool) string {
+	if !typed {
+		return ""
+	}
+
+	return v.Type().String()
+}
+
+// ---
+
+type visitedRef struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// ---
+
+// groupDigits renders the number with digits grouped by thousands, like `99,980`.
+func groupDigits(n int) string {
+	digits := strconv.Itoa(n)
+
+	var sb strings.Builder
+
+	for i, digit := range digits {
+		if i != 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteRune(',')
+		}
+
+		sb.WriteRune(digit)
+	}
+
+	return sb.String()
+}
+
+// elidable reports whether the type name can be elided for elements of type t
+// in slices, arrays and maps, like in composite literals in Go.
+func elidable(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// ---
+
+var defaultPrinter = &printer{
+	maxDepth:         10,
+	maxLength:        20,
+	maxStringLength:  1000,
+	hexDumpThreshold: 64,
+}
+
+// formatters holds formatters registered using [RegisterFormatter] by type.
+var formatters struct {
+	sync.RWMutex
+	list formatterList
+}
+
+var (
+	goStringerType = reflect.TypeFor[fmt.GoStringer]()
+	secretType     = reflect.TypeFor[Secret]()
+)
+
+const maxInlineWidth = 80
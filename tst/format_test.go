@@ -0,0 +1,203 @@
+package tst_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type node struct {
+	Name     string
+	Next     *node
+	Children []*node
+	Labels   map[string]int
+}
+
+func TestValueRendering(t *testing.T) {
+	cyclic := &node{Name: "a"}
+	cyclic.Next = cyclic
+
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"pointer", &node{Name: "a"}, "&tst_test.node{\n        Name: \"a\",\n        Next: (*tst_test.node)(nil),"},
+		{"sorted-map", map[string]int{"b": 2, "c": 3, "a": 1}, `map[string]int{"a": 1, "b": 2, "c": 3}`},
+		{"cycle", cyclic, `Next: <cycle>,`},
+		{"elided-types", [][]int{{1}, {2, 3}}, `[][]int{{1}, {2, 3}}`},
+		{"bytes", []byte{1, 255}, `[]uint8{0x01, 0xff}`},
+		{"multi-line", &node{
+			Name:     "root",
+			Children: []*node{{Name: "child", Labels: map[string]int{"x": 1}}},
+		}, strings.Join([]string{
+			`&tst_test.node{`,
+			`    Name: "root",`,
+			`    Next: (*tst_test.node)(nil),`,
+			`    Children: []*tst_test.node{`,
+			`        {`,
+			`            Name: "child",`,
+			`            Next: (*tst_test.node)(nil),`,
+			`            Children: []*tst_test.node(nil),`,
+			`            Labels: map[string]int{"x": 1},`,
+			`        },`,
+			`    },`,
+			`    Labels: map[string]int(nil),`,
+			`}`,
+		}, "\n    ")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.value).To(tst.BeNil())
+			})
+			if !failed || !strings.Contains(output, tc.expected) {
+				t.Fatalf("Expected output to contain %s, got output: %s", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestValueRenderingLimits(t *testing.T) {
+	tst.SetDefaultFormat(tst.MaxDepth(2), tst.MaxLength(3))
+	defer tst.SetDefaultFormat(tst.MaxDepth(10), tst.MaxLength(20))
+
+	output, _ := run(func(t tst.Test) {
+		t.Expect([]int{1, 2, 3, 4, 5}, [][][]int{{{1}}}).To(tst.BeNil())
+	})
+
+	for _, expected := range []string{`[]int{1, 2, … 2 more …, 5}`, `[][][]int{{{...}}}`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain %s, got output: %s", expected, output)
+		}
+	}
+}
+
+func TestValueRenderingUsesGoString(t *testing.T) {
+	output, _ := run(func(t tst.Test) {
+		t.Expect(struct{ At time.Time }{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}).To(tst.BeNil())
+	})
+
+	if !strings.Contains(output, "At: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)") {
+		t.Fatalf("Expected time to be rendered using GoString, got output: %s", output)
+	}
+}
+
+type amount struct {
+	units int64
+	nanos int32
+}
+
+type percent float64
+
+func (p percent) TstFormat() string {
+	return fmt.Sprintf("%g%%", float64(p))
+}
+
+type order struct {
+	Total    amount
+	Discount percent
+}
+
+func TestCustomFormatters(t *testing.T) {
+	tst.RegisterFormatter(func(a amount) string {
+		return fmt.Sprintf("$%d.%02d", a.units, a.nanos/10000000)
+	})
+
+	value := order{amount{12, 340000000}, 15}
+
+	output, _ := run(func(t tst.Test) {
+		t.Expect(value).To(tst.BeNil())
+	})
+
+	if !strings.Contains(output, "tst_test.order{Total: $12.34, Discount: 15%}") {
+		t.Fatalf("Expected custom formatters to be used, got output: %s", output)
+	}
+
+	output, _ = run(func(t tst.Test) {
+		tt := tst.New(t, tst.WithFormatter(func(a amount) string {
+			return fmt.Sprintf("%d.%09d USD", a.units, a.nanos)
+		}))
+		tt.Expect(value.Total).To(tst.BeNil())
+	})
+
+	if !strings.Contains(output, "<tst_test.amount>: 12.340000000 USD") {
+		t.Fatalf("Expected per-test formatter to be used, got output: %s", output)
+	}
+}
+
+func TestValueElision(t *testing.T) {
+	values := make([]int, 100000)
+	for i := range values {
+		values[i] = i
+	}
+
+	output, _ := run(func(t tst.Test) {
+		t.Expect(values).To(tst.HaveLen(3))
+	})
+
+	if !strings.Contains(output, "    9,\n        … 99,980 more …,\n        99990,") || strings.Contains(output, "50000") {
+		t.Fatalf("Expected the middle of the slice to be elided, got output: %s", output)
+	}
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(strings.Repeat("ab", 50), values).WithFormat(tst.MaxStringLength(5), tst.MaxLength(2)).To(tst.BeNil())
+	})
+
+	for _, expected := range []string{`"ababa"… (100 bytes)`, `[]int{0, … 99,998 more …, 99999}`} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain %s, got output: %s", expected, output)
+		}
+	}
+}
+
+type labeler interface {
+	Label() string
+}
+
+type namer interface {
+	Name() string
+}
+
+type badge string
+
+func (b badge) Label() string { return "label " + string(b) }
+func (b badge) Name() string  { return "name " + string(b) }
+
+func TestFormatterOrder(t *testing.T) {
+	tst.RegisterFormatter(func(l labeler) string { return l.Label() })
+	tst.RegisterFormatter(func(n namer) string { return n.Name() })
+
+	for range 20 {
+		output, _ := run(func(t tst.Test) {
+			t.Expect(badge("x")).To(tst.BeNil())
+		})
+
+		if !strings.Contains(output, "<tst_test.badge>: label x") {
+			t.Fatalf("Expected the first registered interface formatter to be used, got output: %s", output)
+		}
+	}
+
+	output, _ := run(func(t tst.Test) {
+		tt := tst.New(t, tst.WithFormatter(func(n namer) string { return n.Name() }))
+		tt.Expect(badge("x")).To(tst.BeNil())
+	})
+
+	if !strings.Contains(output, "<tst_test.badge>: name x") {
+		t.Fatalf("Expected the per-test interface formatter to be used, got output: %s", output)
+	}
+
+	tst.RegisterFormatter(func(b badge) string { return "badge " + string(b) })
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(badge("x")).To(tst.BeNil())
+	})
+
+	if !strings.Contains(output, "<tst_test.badge>: badge x") {
+		t.Fatalf("Expected the exact type formatter to be used, got output: %s", output)
+	}
+}
@@ -0,0 +1,124 @@
+package tst_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+// ---
+
+// mockT is a test double for [testing.T] that records logged messages and failures.
+type mockT struct {
+	testing.TB
+	logs    []string
+	failed  bool
+	cleanup []func()
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Name() string {
+	return "mock"
+}
+
+func (m *mockT) Log(args ...any) {
+	m.logs = append(m.logs, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+func (m *mockT) Logf(format string, args ...any) {
+	m.logs = append(m.logs, fmt.Sprintf(format, args...))
+}
+
+func (m *mockT) Fatal(args ...any) {
+	m.Log(args...)
+	m.FailNow()
+}
+
+func (m *mockT) Fail() {
+	m.failed = true
+}
+
+func (m *mockT) FailNow() {
+	m.failed = true
+	panic(errFailNow)
+}
+
+func (m *mockT) Failed() bool {
+	return m.failed
+}
+
+func (m *mockT) Cleanup(f func()) {
+	m.cleanup = append(m.cleanup, f)
+}
+
+func (m *mockT) Run(_ string, f func(*mockT)) bool {
+	f(m)
+
+	return !m.failed
+}
+
+func (m *mockT) output() string {
+	return strings.Join(m.logs, "\n")
+}
+
+// ---
+
+// run runs f against a mock test and returns the resulting output and failure status.
+// Colors are disabled to keep the output independent of the environment.
+func run(f func(tst.Test)) (output string, failed bool) {
+	m := &mockT{}
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil && r != errFailNow {
+				panic(r)
+			}
+		}()
+
+		f(tst.New(m, tst.WithFormat(tst.Colors(false))))
+	}()
+
+	return m.output(), m.failed
+}
+
+// ---
+
+// assertionCase is a case of a table test that tests a single value against an assertion.
+// Failure is a fragment of the output expected when the assertion fails, it is empty if the assertion must pass.
+type assertionCase struct {
+	name      string
+	actual    any
+	assertion tst.Assertion
+	failure   string
+}
+
+// testAssertions runs each of the cases in a sub-test and checks
+// that the assertion passes or fails with the expected output.
+func testAssertions(t *testing.T, cases []assertionCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+
+			switch {
+			case failed && tc.failure == "":
+				t.Fatalf("Expected assertion to pass, got output: %s", output)
+			case !failed && tc.failure != "":
+				t.Fatalf("Expected assertion to fail with %q, but it passed", tc.failure)
+			case !strings.Contains(output, tc.failure):
+				t.Fatalf("Expected output to contain %q, got: %s", tc.failure, output)
+			}
+		})
+	}
+}
+
+// ---
+
+var errFailNow = errors.New("fail now")
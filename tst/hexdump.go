@@ -0,0 +1,155 @@
+package tst
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// HexDumpThreshold returns a format option that makes byte slices and arrays longer than n bytes
+// rendered as a hex dump with offsets, hexadecimal bytes and their ASCII representation,
+// and makes [Equal] report differences of such values as a hex dump of the differing rows.
+func HexDumpThreshold(n int) FormatOption {
+	return func(p *printer) {
+		p.hexDumpThreshold = n
+	}
+}
+
+// ---
+
+// hexDump renders data as hex dump rows eliding the rows in the middle in case there are too many of them.
+func (p *printer) hexDump(data []byte) []string {
+	return p.elide(hexDumpRows(len(data)), func(i int) string {
+		return hexDumpRow(i*hexDumpWidth, hexDumpRowOf(data, i))
+	})
+}
+
+// hexDiff renders rows of actual and expected data that differ
+// with markers under the differing bytes.
+func (p *printer) hexDiff(actual, expected []byte) string {
+	var sb strings.Builder
+	sb.WriteString("Hex dump differences (- actual, + expected):")
+
+	n := 0
+
+	for i := range max(hexDumpRows(len(actual)), hexDumpRows(len(expected))) {
+		x, y := hexDumpRowOf(actual, i), hexDumpRowOf(expected, i)
+
+		markers := hexDumpMarkers(x, y)
+		if markers == "" {
+			continue
+		}
+
+		if n == maxDifferences {
+			fmt.Fprintf(&sb, "\n%s...", indentSnippet)
+
+			break
+		}
+
+		n++
+
+		fmt.Fprintf(&sb, "\n%s%s", indentSnippet, p.paint(colorActual, "-"+hexDumpRow(i*hexDumpWidth, x)))
+		fmt.Fprintf(&sb, "\n%s%s", indentSnippet, p.paint(colorExpected, "+"+hexDumpRow(i*hexDumpWidth, y)))
+		fmt.Fprintf(&sb, "\n%s %s", indentSnippet, p.paint(colorMarker, strings.TrimRight(markers, " ")))
+	}
+
+	return sb.String()
+}
+
+// ---
+
+func hexDumpRows(n int) int {
+	return (n + hexDumpWidth - 1) / hexDumpWidth
+}
+
+func hexDumpRowOf(data []byte, i int) []byte {
+	return data[min(i*hexDumpWidth, len(data)):min((i+1)*hexDumpWidth, len(data))]
+}
+
+// hexDumpRow renders a row of up to hexDumpWidth bytes like `00000010  48 65 6c 6c 6f 20 77 6f  72 6c 64 0a  |Hello world.|`.
+func hexDumpRow(offset int, row []byte) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%08x ", offset)
+
+	for i := range hexDumpWidth {
+		sb.WriteString(hexDumpGap(i))
+
+		if i < len(row) {
+			fmt.Fprintf(&sb, "%02x", row[i])
+		} else {
+			sb.WriteString("  ")
+		}
+	}
+
+	sb.WriteString("  |")
+
+	for _, b := range row {
+		if b >= 0x20 && b < 0x7f {
+			sb.WriteByte(b)
+		} else {
+			sb.WriteByte('.')
+		}
+	}
+
+	sb.WriteString(strings.Repeat(" ", hexDumpWidth-len(row)))
+	sb.WriteString("|")
+
+	return sb.String()
+}
+
+// hexDumpMarkers returns a line with `^^` markers under the bytes that differ in rows x and y
+// aligned with the rows rendered by hexDumpRow, or an empty string if the rows are equal.
+func hexDumpMarkers(x, y []byte) string {
+	var sb strings.Builder
+
+	found := false
+
+	sb.WriteString(strings.Repeat(" ", 9))
+
+	for i := range hexDumpWidth {
+		sb.WriteString(hexDumpGap(i))
+
+		if i < max(len(x), len(y)) && (i >= len(x) || i >= len(y) || x[i] != y[i]) {
+			sb.WriteString("^^")
+
+			found = true
+		} else {
+			sb.WriteString("  ")
+		}
+	}
+
+	if !found {
+		return ""
+	}
+
+	return sb.String()
+}
+
+func hexDumpGap(i int) string {
+	if i == hexDumpWidth/2 {
+		return "  "
+	}
+
+	return " "
+}
+
+// bytesOf returns contents of a slice or an array of bytes.
+func bytesOf(v reflect.Value) ([]byte, bool) {
+	switch {
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return v.Bytes(), true
+	case v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8:
+		data := make([]byte, v.Len())
+		for i := range data {
+			data[i] = byte(v.Index(i).Uint())
+		}
+
+		return data, true
+	default:
+		return nil, false
+	}
+}
+
+// ---
+
+const hexDumpWidth = 16
@@ -0,0 +1,48 @@
+package tst_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestHexDump(t *testing.T) {
+	actual := []byte("Hello, world!\x00\x01\x02")
+	expected := bytes.Clone(actual)
+	expected[4] = 'O'
+	expected = append(expected, 0xff)
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(actual).WithFormat(tst.HexDumpThreshold(8)).ToEqual(expected)
+	})
+	if !failed {
+		t.Fatalf("Expected ToEqual to fail")
+	}
+
+	for _, expected := range []string{
+		"<[]uint8>: [16] []uint8{\n        00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64 21 00 01 02  |Hello, world!...|\n    }",
+		strings.Join([]string{
+			"Hex dump differences (- actual, + expected):",
+			"    -00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64 21 00 01 02  |Hello, world!...|",
+			"    +00000000  48 65 6c 6c 4f 2c 20 77  6f 72 6c 64 21 00 01 02  |HellO, world!...|",
+			"                           ^^",
+			"    -00000010                                                    |                |",
+			"    +00000010  ff                                                |.               |",
+			"               ^^",
+		}, "\n"),
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain\n%s\ngot output: %s", expected, output)
+		}
+	}
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(actual).ToEqual(expected)
+	})
+
+	if !strings.Contains(output, `| "Hello, world!\x00\x01\x02"`) || strings.Contains(output, "Hex dump") {
+		t.Fatalf("Expected short byte slices to be rendered inline, got output: %s", output)
+	}
+}
@@ -0,0 +1,288 @@
+package tst
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MatchJSON returns an assertion that passes in case all the values to be tested are JSON documents
+// semantically equal to the specified JSON document, ignoring object key order, white space,
+// and differences in number formatting.
+//
+// Both the values to test and the expected document can be strings, byte slices, [json.RawMessage]
+// or values implementing [fmt.Stringer].
+// Values of differences are redacted if either document is a [Secret].
+func MatchJSON(expected any) Assertion {
+	return matchJSON{"MatchJSON", expected, false}
+}
+
+// MatchJSONSubset returns an assertion that passes in case all the values to be tested are JSON documents
+// containing the specified JSON document.
+// It is like [MatchJSON] but objects in the values to test may have additional keys not present in the expected document.
+// Arrays must have the same length and their elements are matched in order.
+func MatchJSONSubset(expected any) Assertion {
+	return matchJSON{"MatchJSONSubset", expected, true}
+}
+
+// ---
+
+type matchJSON struct {
+	matcher
+
+	expected any
+	subset   bool
+}
+
+func (a matchJSON) check(actual []any) ([]bool, error) {
+	expected, err := a.parseExpected()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		actual, err := parseJSON(actual[i])
+		if err != nil {
+			return nil, fmt.Errorf("value to test #%d: %w", i+1, err)
+		}
+
+		d := jsonDiffer{subset: a.subset, limit: 1}
+		d.walk(actual, expected, "")
+		result[i] = len(d.differences) == 0
+	}
+
+	return result, nil
+}
+
+func (a matchJSON) description(p *printer) string {
+	what := "match JSON"
+	if a.subset {
+		what = "match JSON subset"
+	}
+
+	return what + "\n" + indent(1, value{a.expected}.description(p))
+}
+
+func (a matchJSON) complexity() int {
+	return 1
+}
+
+func (a matchJSON) at(int) Assertion {
+	return a
+}
+
+func (a matchJSON) explain(p *printer, actual any) string {
+	expected, err := a.parseExpected()
+	if err != nil {
+		return ""
+	}
+
+	doc, err := parseJSON(actual)
+	if err != nil {
+		return ""
+	}
+
+	d := jsonDiffer{subset: a.subset, limit: maxDifferences + 1}
+	d.walk(doc, expected, "")
+
+	if len(d.differences) == 0 {
+		return ""
+	}
+
+	_, actualSecret := actual.(Secret)
+	_, expectedSecret := a.expected.(Secret)
+
+	text := make(textDifferences, len(d.differences))
+	for i, diff := range d.differences {
+		if actualSecret || expectedSecret {
+			text[i] = textDifference{diff.path, redacted, redacted}
+		} else {
+			text[i] = textDifference{diff.path, jsonText(p, diff.actual, diff.actualMissing), jsonText(p, diff.expected, diff.expectedMissing)}
+		}
+	}
+
+	return text.description(p)
+}
+
+func (a matchJSON) parseExpected() (any, error) {
+	expected, err := parseJSON(a.expected)
+	if err != nil {
+		return nil, fmt.Errorf("value in assertion: %w", err)
+	}
+
+	return expected, nil
+}
+
+// ---
+
+type jsonDifference struct {
+	path            string
+	actual          any
+	expected        any
+	actualMissing   bool
+	expectedMissing bool
+}
+
+// ---
+
+type jsonDiffer struct {
+	subset      bool
+	limit       int
+	differences []jsonDifference
+}
+
+func (d *jsonDiffer) report(diff jsonDifference) {
+	if len(d.differences) < d.limit {
+		d.differences = append(d.differences, diff)
+	}
+}
+
+func (d *jsonDiffer) walk(actual, expected any, path string) {
+	if len(d.differences) >= d.limit {
+		return
+	}
+
+	switch expected := expected.(type) {
+	case map[string]any:
+		actual, ok := actual.(map[string]any)
+		if !ok {
+			break
+		}
+
+		for _, key := range sortedKeys(expected) {
+			if value, ok := actual[key]; ok {
+				d.walk(value, expected[key], jsonPointer(path, key))
+			} else {
+				d.report(jsonDifference{path: jsonPointer(path, key), expected: expected[key], actualMissing: true})
+			}
+		}
+
+		if !d.subset {
+			for _, key := range sortedKeys(actual) {
+				if _, ok := expected[key]; !ok {
+					d.report(jsonDifference{path: jsonPointer(path, key), actual: actual[key], expectedMissing: true})
+				}
+			}
+		}
+
+		return
+
+	case []any:
+		actual, ok := actual.([]any)
+		if !ok {
+			break
+		}
+
+		n := min(len(actual), len(expected))
+		for i := range n {
+			d.walk(actual[i], expected[i], jsonPointer(path, strconv.Itoa(i)))
+		}
+
+		for i := n; i < len(actual); i++ {
+			d.report(jsonDifference{path: jsonPointer(path, strconv.Itoa(i)), actual: actual[i], expectedMissing: true})
+		}
+
+		for i := n; i < len(expected); i++ {
+			d.report(jsonDifference{path: jsonPointer(path, strconv.Itoa(i)), expected: expected[i], actualMissing: true})
+		}
+
+		return
+
+	case json.Number:
+		if actual, ok := actual.(json.Number); ok && equalJSONNumbers(actual, expected) {
+			return
+		}
+
+	default:
+		if actual == expected {
+			return
+		}
+	}
+
+	d.report(jsonDifference{path: path, actual: actual, expected: expected})
+}
+
+// ---
+
+func parseJSON(v any) (any, error) {
+	text, ok := stringOf(v)
+	if !ok {
+		return nil, fmt.Errorf("expected JSON document of type string or []byte but got <%s>", typeOf(v))
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid JSON: unexpected data after top-level value")
+	}
+
+	return doc, nil
+}
+
+func equalJSONNumbers(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+
+	x, xok := new(big.Rat).SetString(string(a))
+	y, yok := new(big.Rat).SetString(string(b))
+
+	return xok && yok && x.Cmp(y) == 0
+}
+
+// jsonPointer appends the reference token to the JSON pointer as defined in RFC 6901.
+func jsonPointer(path, token string) string {
+	return path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// jsonText returns the decoded JSON value v as compact JSON or a placeholder if it is missing.
+// The text is redacted and truncated the same way as strings rendered by the printer.
+func jsonText(p *printer, v any, missing bool) string {
+	if missing {
+		return "<missing>"
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(v)
+	if err != nil {
+		return value{v}.description(p)
+	}
+
+	text := p.scrub(strings.TrimSuffix(buf.String(), "\n"))
+
+	cut, ok := p.truncate(text)
+	if !ok {
+		return text
+	}
+
+	return fmt.Sprintf("%s… (%s bytes)", cut, groupDigits(len(text)))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
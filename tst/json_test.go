@@ -0,0 +1,100 @@
+package tst_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestJSON(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"key-order", `{"a": 1, "b": 2}`, tst.MatchJSON(`{"b":2,"a":1}`), ""},
+		{"white-space", "[1,\n 2 ]", tst.MatchJSON(`[1,2]`), ""},
+		{"number-format", `{"a": 1.0, "b": 1e2, "c": -0.50}`, tst.MatchJSON(`{"a": 1, "b": 100, "c": -0.5}`), ""},
+		{"number-mismatch", `{"a": 1.01}`, tst.MatchJSON(`{"a": 1}`), "/a:\n        actual   1.01\n        expected 1"},
+		{"number-vs-string", `{"a": 1}`, tst.MatchJSON(`{"a": "1"}`), "/a:\n        actual   1\n        expected \"1\""},
+		{"extra-key", `{"a": 1, "b": 2}`, tst.MatchJSON(`{"a": 1}`), "/b:\n        actual   2\n        expected <missing>"},
+		{"missing-key", `{"a": 1}`, tst.MatchJSON(`{"a": 1, "b": 2}`), "/b:\n        actual   <missing>\n        expected 2"},
+		{"array-order", `[1, 2]`, tst.MatchJSON(`[2, 1]`), "/0:\n        actual   1\n        expected 2"},
+		{"bytes", []byte(`{"a": null}`), tst.MatchJSON(json.RawMessage(`{"a":null}`)), ""},
+		{"subset-extra-key", `{"a": 1, "b": {"c": 2, "d": 3}}`, tst.MatchJSONSubset(`{"b": {"c": 2}}`), ""},
+		{"subset-missing-key", `{"a": 1}`, tst.MatchJSONSubset(`{"b": 1}`), "/b:\n        actual   <missing>\n        expected 1"},
+		{"subset-value-mismatch", `{"a": 1, "b": 2}`, tst.MatchJSONSubset(`{"b": 3}`), "/b:\n        actual   2\n        expected 3"},
+		{"subset-array-length", `{"a": [1, 2]}`, tst.MatchJSONSubset(`{"a": [1]}`), "/a/1:\n        actual   2\n        expected <missing>"},
+		{"subset-array-elements", `[{"a": 1, "b": 2}]`, tst.MatchJSONSubset(`[{"a": 1}]`), ""},
+	})
+}
+
+func TestJSONDifferences(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(`{"a/b": {"c~d": [1, 2]}, "e": "x"}`).To(tst.MatchJSON(`{"a/b": {"c~d": [1, 3]}, "f": true}`))
+	})
+	if !failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", output)
+	}
+
+	for _, expected := range []string{
+		"/a~1b/c~0d/1:\n        actual   2\n        expected 3\n",
+		"/e:\n        actual   \"x\"\n        expected <missing>",
+		"/f:\n        actual   <missing>\n        expected true",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestJSONRedaction(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(`{"token": "abc"}`).To(tst.MatchJSON(tst.Secret(`{"token": "t0ken"}`)))
+	})
+	if !failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", output)
+	}
+
+	if strings.Contains(output, "t0ken") {
+		t.Fatalf("Expected secret to be redacted, got output: %s", output)
+	}
+}
+
+func TestJSONTruncation(t *testing.T) {
+	long := strings.Repeat("x", 2000)
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(`{"a": "` + long + `"}`).To(tst.MatchJSON(`{"a": "y"}`))
+	})
+	if !failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", output)
+	}
+
+	if strings.Contains(output, long) || !strings.Contains(output, "actual   \""+long[:999]+"… (2,002 bytes)\n") {
+		t.Fatalf("Expected long string to be truncated, got output: %s", output)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"invalid-actual", `{"a": }`, tst.MatchJSON(`{}`), "value to test #1: invalid JSON"},
+		{"trailing-actual", `{} {}`, tst.MatchJSON(`{}`), "unexpected data after top-level value"},
+		{"invalid-expected", `{}`, tst.MatchJSONSubset(`{`), "value in assertion: invalid JSON"},
+		{"unsupported-type", 42, tst.MatchJSON(`42`), "expected JSON document of type string or []byte"},
+	})
+}
+
+func TestJSONScrubbing(t *testing.T) {
+	m := &mockT{}
+
+	tt := tst.New(m, tst.WithScrubber(regexp.MustCompile(`s3cr3t-[a-z]+`)), tst.Soft(true))
+	tt.Expect(`{"token": "s3cr3t-abc"}`).To(tst.MatchJSON(`{"token": "s3cr3t-xyz"}`))
+
+	if !m.failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", m.output())
+	}
+
+	if !strings.Contains(m.output(), "/token:\n        actual   \"<redacted>\"\n        expected \"<redacted>\"") {
+		t.Fatalf("Expected differences to be scrubbed, got output: %s", m.output())
+	}
+}
@@ -2,10 +2,9 @@ package tst
 
 import (
 	"fmt"
-	"os"
 	"runtime"
-	"slices"
 	"strings"
+	"sync"
 )
 
 // ThisLine returns a LineTag that represents the line where it was called.
@@ -30,9 +29,17 @@ func (t LineTag) IsZero() bool {
 }
 
 // String returns a string representation of the LineTag.
+// The file path is shortened unless full paths are enabled by the TST_FULL_PATH environment variable
+// or the `-test.fullpath` flag, which are resolved once on the first call.
+// Options passed to [New] do not apply here, use [Test.AddLineTags] to render line tags the way the test does.
+// Invalid settings are reported by [New] and fall back to the defaults here.
 func (t LineTag) String() string {
-	fn := runtime.FuncForPC(t.pc)
-	file, line := fn.FileLine(t.pc)
+	return t.format(defaultFullPath())
+}
+
+// format returns a string representation of the LineTag with a full or shortened file path.
+func (t LineTag) format(fullPath bool) string {
+	file, line := t.fileLine()
 
 	shorten := func(file string) string {
 		e := len(file)
@@ -55,13 +62,23 @@ func (t LineTag) String() string {
 	return fmt.Sprintf("%s:%d", file, line)
 }
 
+// fileLine returns the full path of the file and the line number.
+func (t LineTag) fileLine() (string, int) {
+	return runtime.FuncForPC(t.pc).FileLine(t.pc)
+}
+
 // ---
 
+// defaultFullPath reports whether line tags are rendered with full file paths outside of tests.
+var defaultFullPath = sync.OnceValue(func() bool {
+	c, err := defaults()
+
+	return err == nil && c.printer.fullPath
+})
+
 func callerLine(skip int) LineTag {
 	var pcs [1]uintptr
 	runtime.Callers(2+skip, pcs[:])
 
 	return LineTag{pcs[0]}
 }
-
-var fullPath = slices.Contains(os.Args, "-test.fullpath=true")
@@ -0,0 +1,540 @@
+package tst
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"reflect"
+)
+
+// BeNumerically returns an assertion that passes in case all the values to be tested are numbers
+// that relate to the specified number according to the operator op.
+// Supported operators are "==", "!=", "<", "<=", ">", ">=" and "~".
+// Operator "~" tests for approximate equality and accepts an optional tolerance that defaults to 1e-8,
+// other operators accept no tolerance.
+// NaN is not equal to any number including NaN, and fails all ordered comparisons.
+//
+// Numbers of different kinds, including integer, floating point, complex numbers,
+// and [big.Int], [big.Float] and [big.Rat] pointers, can be compared with each other.
+// Complex numbers support only "==", "!=" and "~" operators.
+func BeNumerically(op string, expected any, tolerance ...any) Assertion {
+	if op == "~" && len(tolerance) > 1 {
+		return invalid{approx{"BeNumerically", expected, tolerance[0]}, errTooManyArgumentsError{op, len(tolerance), 1}}
+	}
+
+	if op != "~" && len(tolerance) != 0 {
+		return invalid{BeNumerically(op, expected), errTooManyArgumentsError{op, len(tolerance), 0}}
+	}
+
+	switch op {
+	case "~":
+		if len(tolerance) == 0 {
+			return approx{"BeNumerically", expected, defaultTolerance}
+		}
+
+		return approx{"BeNumerically", expected, tolerance[0]}
+	case "==":
+		return numeric{"BeNumerically", op, expected, func(r int) bool { return r == 0 }}
+	case "!=":
+		return numeric{"BeNumerically", op, expected, func(r int) bool { return r != 0 }}
+	case "<":
+		return numeric{"BeNumerically", op, expected, lt}
+	case "<=":
+		return numeric{"BeNumerically", op, expected, le}
+	case ">":
+		return numeric{"BeNumerically", op, expected, gt}
+	case ">=":
+		return numeric{"BeNumerically", op, expected, ge}
+	default:
+		return numeric{"BeNumerically", op, expected, nil}
+	}
+}
+
+// BeApprox returns an assertion that passes in case all the values to be tested are numbers
+// that differ from the specified number by no more than the specified tolerance.
+func BeApprox(expected, tolerance any) Assertion {
+	return approx{"BeApprox", expected, tolerance}
+}
+
+// BeWithinULPs returns an assertion that passes in case all the values to be tested are floating point numbers
+// that are no more than n units in the last place (ULPs) away from the specified number.
+// Values of type float32 are compared in float32 precision if the expected value fits into float32 exactly.
+func BeWithinULPs(expected any, n uint) Assertion {
+	return withinULPs{"BeWithinULPs", expected, n}
+}
+
+// BeNaN returns an assertion that passes in case all the values to be tested
+// are floating point or complex numbers that are NaN.
+func BeNaN() Assertion {
+	return nan{"BeNaN"}
+}
+
+// BeInf returns an assertion that passes in case all the values to be tested
+// are floating point or complex numbers or [big.Float] values that are infinite.
+func BeInf() Assertion {
+	return inf{"BeInf"}
+}
+
+// ---
+
+type numeric struct {
+	matcher
+
+	op             string
+	expected       any
+	expectedResult func(int) bool
+}
+
+func (a numeric) check(actual []any) ([]bool, error) {
+	if a.expectedResult == nil {
+		return nil, errUnsupportedOperatorError{a.op}
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		actual, expected := reflect.ValueOf(actual[i]), reflect.ValueOf(a.expected)
+
+		if (a.op == "==" || a.op == "!=") && (isComplex(actual) || isComplex(expected)) {
+			x, xok := complexOf(actual)
+			y, yok := complexOf(expected)
+			if !xok || !yok {
+				return nil, errUnexpectedValueTypeError{i, typeOf(valueOf(actual)), typeOf(a.expected)}
+			}
+
+			result[i] = (x == y) == (a.op == "==")
+
+			continue
+		}
+
+		r, err := compareNumeric(i, actual, expected)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = a.expectedResult(r)
+	}
+
+	return result, nil
+}
+
+func (a numeric) description(p *printer) string {
+	return "be numerically " + a.op + "\n" + indent(1, value{a.expected}.description(p))
+}
+
+func (a numeric) complexity() int {
+	return 1
+}
+
+func (a numeric) at(int) Assertion {
+	return a
+}
+
+// ---
+
+// invalid is an assertion that was constructed with invalid arguments and fails with the error on check.
+type invalid struct {
+	Assertion
+	err error
+}
+
+func (a invalid) check([]any) ([]bool, error) {
+	return nil, a.err
+}
+
+func (a invalid) at(int) Assertion {
+	return a
+}
+
+func (a invalid) matcherName() string {
+	return matcherName(a.Assertion)
+}
+
+// ---
+
+type approx struct {
+	matcher
+
+	expected  any
+	tolerance any
+}
+
+func (a approx) check(actual []any) ([]bool, error) {
+	tolerance, ok := numberOf(reflect.ValueOf(a.tolerance))
+	if !ok || tolerance.nan || tolerance.sign() < 0 {
+		return nil, errUnexpectedAssertionTypeError{1, typeOf(a.tolerance), "non-negative real number"}
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		actual, expected := reflect.ValueOf(actual[i]), reflect.ValueOf(a.expected)
+
+		x, xok := numberOf(actual)
+		y, yok := numberOf(expected)
+
+		switch {
+		case xok && yok:
+			result[i] = x.near(y, tolerance)
+		default:
+			x, xok := complexOf(actual)
+			y, yok := complexOf(expected)
+			if !xok || !yok {
+				return nil, errUnexpectedValueTypeError{i, typeOf(valueOf(actual)), typeOf(a.expected)}
+			}
+
+			result[i] = cmplx.Abs(x-y) <= tolerance.float64()
+		}
+	}
+
+	return result, nil
+}
+
+func (a approx) description(p *printer) string {
+	return fmt.Sprintf("be within %v of\n%s", a.tolerance, indent(1, value{a.expected}.description(p)))
+}
+
+func (a approx) complexity() int {
+	return 1
+}
+
+func (a approx) at(int) Assertion {
+	return a
+}
+
+// ---
+
+type withinULPs struct {
+	matcher
+
+	expected any
+	n        uint
+}
+
+func (a withinULPs) check(actual []any) ([]bool, error) {
+	expected, ok := numberOf(reflect.ValueOf(a.expected))
+	if !ok {
+		return nil, errUnexpectedAssertionTypeError{0, typeOf(a.expected), "real number"}
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		v := reflect.ValueOf(actual[i])
+
+		switch v.Kind() {
+		case reflect.Float32:
+			y := expected.float64()
+			if float64(float32(y)) == y || math.IsNaN(y) {
+				result[i] = ulps32(float32(v.Float()), float32(y)) <= uint64(a.n)
+			} else {
+				result[i] = ulps64(v.Float(), y) <= uint64(a.n)
+			}
+		case reflect.Float64:
+			result[i] = ulps64(v.Float(), expected.float64()) <= uint64(a.n)
+		default:
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "floating point number"}
+		}
+	}
+
+	return result, nil
+}
+
+func (a withinULPs) description(p *printer) string {
+	return fmt.Sprintf("be within %d ULPs of\n%s", a.n, indent(1, value{a.expected}.description(p)))
+}
+
+func (a withinULPs) complexity() int {
+	return 1
+}
+
+func (a withinULPs) at(int) Assertion {
+	return a
+}
+
+// ---
+
+type nan struct {
+	matcher
+}
+
+func (a nan) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		v := reflect.ValueOf(actual[i])
+
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			result[i] = math.IsNaN(v.Float())
+		case reflect.Complex64, reflect.Complex128:
+			result[i] = cmplx.IsNaN(v.Complex())
+		default:
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "floating point or complex number"}
+		}
+	}
+
+	return result, nil
+}
+
+func (a nan) description(*printer) string {
+	return "be NaN"
+}
+
+func (a nan) complexity() int {
+	return 1
+}
+
+func (a nan) at(int) Assertion {
+	return a
+}
+
+// ---
+
+type inf struct {
+	matcher
+}
+
+func (a inf) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		v := reflect.ValueOf(actual[i])
+
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			result[i] = math.IsInf(v.Float(), 0)
+		case reflect.Complex64, reflect.Complex128:
+			result[i] = cmplx.IsInf(v.Complex())
+		default:
+			f, ok := actual[i].(*big.Float)
+			if !ok || f == nil {
+				return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "floating point or complex number"}
+			}
+
+			result[i] = f.IsInf()
+		}
+	}
+
+	return result, nil
+}
+
+func (a inf) description(*printer) string {
+	return "be infinite"
+}
+
+func (a inf) complexity() int {
+	return 1
+}
+
+func (a inf) at(int) Assertion {
+	return a
+}
+
+// ---
+
+// number is an exact representation of any real number value including infinities and NaN.
+type number struct {
+	rat *big.Rat
+	inf int
+	nan bool
+}
+
+func (x number) sign() int {
+	if x.inf != 0 {
+		return x.inf
+	}
+
+	return x.rat.Sign()
+}
+
+// cmp compares x and y the same way as [cmp.Compare] does, but returns unordered if any of them is NaN.
+func (x number) cmp(y number) int {
+	switch {
+	case x.nan || y.nan:
+		return unordered
+	case x.inf != 0 || y.inf != 0:
+		switch {
+		case x.inf < y.inf:
+			return -1
+		case x.inf > y.inf:
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return x.rat.Cmp(y.rat)
+	}
+}
+
+func (x number) near(y, tolerance number) bool {
+	switch {
+	case x.nan || y.nan:
+		return false
+	case x.inf != 0 || y.inf != 0:
+		return x.inf == y.inf || tolerance.inf != 0
+	case tolerance.inf != 0:
+		return true
+	default:
+		diff := new(big.Rat).Sub(x.rat, y.rat)
+
+		return diff.Abs(diff).Cmp(tolerance.rat) <= 0
+	}
+}
+
+func (x number) float64() float64 {
+	switch {
+	case x.nan:
+		return math.NaN()
+	case x.inf != 0:
+		return math.Inf(x.inf)
+	default:
+		f, _ := x.rat.Float64()
+
+		return f
+	}
+}
+
+// ---
+
+func numberOf(v reflect.Value) (number, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{rat: new(big.Rat).SetInt64(v.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{rat: new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint()))}, true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+
+		switch {
+		case math.IsNaN(f):
+			return number{nan: true}, true
+		case math.IsInf(f, 1):
+			return number{inf: 1}, true
+		case math.IsInf(f, -1):
+			return number{inf: -1}, true
+		default:
+			return number{rat: new(big.Rat).SetFloat64(f)}, true
+		}
+	case reflect.Pointer:
+		if v.IsNil() || !v.CanInterface() {
+			return number{}, false
+		}
+
+		switch x := v.Interface().(type) {
+		case *big.Int:
+			return number{rat: new(big.Rat).SetInt(x)}, true
+		case *big.Rat:
+			return number{rat: x}, true
+		case *big.Float:
+			if x.IsInf() {
+				return number{inf: x.Sign()}, true
+			}
+
+			r, _ := x.Rat(nil)
+
+			return number{rat: r}, true
+		}
+	}
+
+	return number{}, false
+}
+
+func isComplex(v reflect.Value) bool {
+	return v.Kind() == reflect.Complex64 || v.Kind() == reflect.Complex128
+}
+
+func complexOf(v reflect.Value) (complex128, bool) {
+	switch v.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex(), true
+	default:
+		x, ok := numberOf(v)
+		if !ok {
+			return 0, false
+		}
+
+		return complex(x.float64(), 0), true
+	}
+}
+
+func compareNumeric(i int, actual, expected reflect.Value) (int, error) {
+	x, xok := numberOf(actual)
+	y, yok := numberOf(expected)
+
+	if !xok || !yok {
+		return 0, errUnexpectedValueTypeError{i, typeOf(valueOf(actual)), typeOf(valueOf(expected))}
+	}
+
+	return x.cmp(y), nil
+}
+
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+
+	return v.Interface()
+}
+
+func ulps64(x, y float64) uint64 {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return math.MaxUint64
+	}
+
+	return distance(orderedBits64(x), orderedBits64(y))
+}
+
+func ulps32(x, y float32) uint64 {
+	if math.IsNaN(float64(x)) || math.IsNaN(float64(y)) {
+		return math.MaxUint64
+	}
+
+	return distance(int64(orderedBits32(x)), int64(orderedBits32(y)))
+}
+
+// orderedBits64 maps x to an integer so that adjacent floating point numbers map to adjacent integers.
+func orderedBits64(x float64) int64 {
+	bits := int64(math.Float64bits(x))
+	if bits < 0 {
+		bits = math.MinInt64 - bits
+	}
+
+	return bits
+}
+
+// orderedBits32 maps x to an integer so that adjacent floating point numbers map to adjacent integers.
+func orderedBits32(x float32) int32 {
+	bits := int32(math.Float32bits(x))
+	if bits < 0 {
+		bits = math.MinInt32 - bits
+	}
+
+	return bits
+}
+
+func distance(a, b int64) uint64 {
+	if a > b {
+		return uint64(a) - uint64(b)
+	}
+
+	return uint64(b) - uint64(a)
+}
+
+// compareFloats compares x and y the same way as [cmp.Compare] does, but returns unordered if any of them is NaN.
+func compareFloats(x, y float64) int {
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return unordered
+	}
+
+	return cmp.Compare(x, y)
+}
+
+// ---
+
+const defaultTolerance = 1e-8
+
+// unordered is a result of comparison involving NaN that fails all ordered comparisons and equality.
+const unordered = math.MinInt
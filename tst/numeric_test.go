@@ -0,0 +1,60 @@
+package tst_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestNumeric(t *testing.T) {
+	x, y := 0.1, 0.2
+	x32, y32 := float32(0.1), float32(0.2)
+
+	testAssertions(t, []assertionCase{
+		{"int-lt-float", 1, tst.BeLessThan(1.5), ""},
+		{"uint-gt-float", uint8(2), tst.BeGreaterThan(1.5), ""},
+		{"float-ge-int", 2.0, tst.BeGreaterOrEqualThan(3), "to be greater or equal than\n    <int>: 3"},
+		{"big-int-vs-float", big.NewInt(3), tst.BeGreaterThan(2.9), ""},
+		{"big-rat-vs-int", big.NewRat(7, 2), tst.BeNumerically("<", 4), ""},
+		{"big-float-vs-uint", big.NewFloat(2.5), tst.BeNumerically(">=", uint(3)), "to be numerically >=\n    <uint>: 3"},
+		{"eq-mixed", 2, tst.BeNumerically("==", 2.0), ""},
+		{"ne-complex", complex(1, 1), tst.BeNumerically("!=", complex(1, 0)), ""},
+		{"approx-default", 1.0 + 1e-10, tst.BeNumerically("~", 1), ""},
+		{"approx-tolerance", 1.05, tst.BeApprox(1, 0.1), ""},
+		{"approx-exceeded", 1.2, tst.BeApprox(1, 0.1), "to be within 0.1 of\n    <int>: 1"},
+		{"approx-complex", complex(1, 1), tst.BeApprox(complex(1, 1.01), 0.1), ""},
+		{"approx-inf", math.Inf(1), tst.BeApprox(math.Inf(1), 0), ""},
+		{"ulps", x + y, tst.BeWithinULPs(0.3, 1), ""},
+		{"ulps-exceeded", x + y, tst.BeWithinULPs(0.3, 0), "to be within 0 ULPs of\n    <float64>: 0.3"},
+		{"ulps-float32", x32 + y32, tst.BeWithinULPs(float32(0.3), 1), ""},
+		{"nan", math.NaN(), tst.BeNaN(), ""},
+		{"not-nan", 1.0, tst.BeNaN(), "to be NaN"},
+		{"complex-nan", complex(math.NaN(), 0), tst.BeNaN(), ""},
+		{"inf", math.Inf(-1), tst.BeInf(), ""},
+		{"big-float-inf", new(big.Float).SetInf(false), tst.BeInf(), ""},
+		{"finite", 1.0, tst.BeInf(), "to be infinite"},
+		{"nan-lt", math.NaN(), tst.BeNumerically("<", 1), "to be numerically <\n    <int>: 1"},
+		{"nan-ge", math.NaN(), tst.BeNumerically(">=", 1), "to be numerically >=\n    <int>: 1"},
+		{"nan-eq-nan", math.NaN(), tst.BeNumerically("==", math.NaN()), "to be numerically ==\n    <float64>: NaN"},
+		{"nan-ne-nan", math.NaN(), tst.BeNumerically("!=", math.NaN()), ""},
+		{"int-gt-nan", 1, tst.BeNumerically(">", math.NaN()), "to be numerically >\n    <float64>: NaN"},
+		{"nan-less-than", math.NaN(), tst.BeLessThan(1.0), "to be less than\n    <float64>: 1"},
+		{"nan-less-or-equal", math.NaN(), tst.BeLessOrEqualThan(math.NaN()), "to be less or equal than\n    <float64>: NaN"},
+		{"float-greater-than-nan", 1.0, tst.BeGreaterThan(math.NaN()), "to be greater than\n    <float64>: NaN"},
+		{"int-less-than-nan", 1, tst.BeLessThan(math.NaN()), "to be less than\n    <float64>: NaN"},
+	})
+}
+
+func TestNumericErrors(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"unknown-operator", 1, tst.BeNumerically("<>", 1), `operator "<>" is not supported`},
+		{"ordered-complex", 1, tst.BeNumerically("<", complex(1, 0)), "expected to have type <complex128>"},
+		{"negative-tolerance", 1, tst.BeApprox(1, -1), "expected to have type <non-negative real number>"},
+		{"not-numeric", "x", tst.BeNaN(), "expected to have type <floating point or complex number> but it has type <string>"},
+		{"not-numeric-bound", "x", tst.BeLessThan(1), "but it has type <string>"},
+		{"extra-tolerance", 1, tst.BeNumerically("~", 1, 0.1, 0.2), "optional arguments"},
+		{"tolerance-without-approx", 1, tst.BeNumerically("==", 1, 0.1), "optional arguments"},
+	})
+}
@@ -0,0 +1,199 @@
+package tst
+
+import (
+	"strings"
+)
+
+// evaluator is an optional interface that can be implemented by a composite [Assertion]
+// to test the values and provide a result tree for each of them, including results of its sub-assertions.
+type evaluator interface {
+	evaluate(actual []any) ([]outcome, error)
+}
+
+// distancer is an optional interface that can be implemented by an [Assertion]
+// to tell how far the value is from passing it, like the number of differences found by [Equal].
+type distancer interface {
+	distance(actual any) int
+}
+
+// ---
+
+// outcome is a node of a result tree produced by testing a single value against an assertion.
+// Value is the value tested by the leaf assertion, and got is the sub-value rendered for a failed outcome.
+// Descriptions, details of leaf assertions and distances are produced only when requested,
+// so that building the tree costs no more than checking the value, and rendering it evaluates nothing again.
+type outcome struct {
+	label       string
+	description describable
+	value       any
+	got         any
+	subValue    bool
+	redacted    bool
+	passed      bool
+	skipped     bool
+	details     string
+	leaf        Assertion
+	children    []outcome
+	combine     func([]outcome) int
+	penalty     int
+}
+
+// phrase is a fixed description of a composite outcome.
+type phrase string
+
+func (d phrase) description(*printer) string {
+	return string(d)
+}
+
+// evaluate tests the values against the assertion and returns the result tree for each of them.
+func evaluate(assertion Assertion, actual []any) ([]outcome, error) {
+	if evaluator, ok := assertion.(evaluator); ok {
+		return evaluator.evaluate(actual)
+	}
+
+	ok, err := assertion.check(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]outcome, len(actual))
+
+	for i := range actual {
+		leaf := assertion
+		if len(actual) != 1 {
+			leaf = assertion.at(i)
+		}
+
+		result[i] = outcome{description: leaf, value: actual[i], passed: ok[i], leaf: leaf}
+	}
+
+	return result, nil
+}
+
+// verdicts returns whether each of the outcomes passed.
+func verdicts(outcomes []outcome, err error) ([]bool, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(outcomes))
+	for i := range outcomes {
+		result[i] = outcomes[i].passed
+	}
+
+	return result, nil
+}
+
+// distance tells how far the value is from passing the assertion.
+// It is zero for passed and skipped outcomes, and at least one for failed outcomes.
+func (o outcome) distance() int {
+	if o.passed || o.skipped {
+		return 0
+	}
+
+	if distancer, ok := o.leaf.(distancer); ok {
+		return max(distancer.distance(o.value), 1)
+	}
+
+	result := o.penalty
+	if o.combine != nil {
+		result += o.combine(o.children)
+	}
+
+	return max(result, 1)
+}
+
+// totalDistance returns the sum of distances of the outcomes.
+func totalDistance(outcomes []outcome) int {
+	result := 0
+	for _, o := range outcomes {
+		result += o.distance()
+	}
+
+	return result
+}
+
+// minDistance returns the least distance of the outcomes that were not skipped.
+func minDistance(outcomes []outcome) int {
+	result := -1
+
+	for _, o := range outcomes {
+		if d := o.distance(); !o.skipped && (result == -1 || d < result) {
+			result = d
+		}
+	}
+
+	return max(result, 0)
+}
+
+// render renders the outcome with a ✓ or ✗ marker followed by its description and body,
+// or with a - marker for a skipped outcome that was not evaluated because the result was already decided.
+func (o outcome) render(p *printer) string {
+	var marker string
+
+	switch {
+	case o.skipped:
+		marker = "- "
+	case o.passed:
+		marker = p.paint(colorExpected, "✓ ")
+	default:
+		marker = p.paint(colorActual, "✗ ")
+	}
+
+	head := ""
+	if o.description != nil {
+		head = o.description.description(p)
+	}
+
+	if o.label != "" {
+		head = strings.TrimSuffix(o.label+" "+head, " ")
+	}
+
+	text := marker + strings.ReplaceAll(head, "\n", "\n  ")
+
+	if body := o.body(p); body != "" {
+		text += "\n" + indent(1, body)
+	}
+
+	return text
+}
+
+// body renders the tested sub-value and details for a failed outcome, and the outcomes of its sub-assertions.
+// Redacted outcomes render neither the sub-value nor anything derived from it.
+func (o outcome) body(p *printer) string {
+	if o.skipped {
+		return ""
+	}
+
+	if o.redacted {
+		if o.subValue && !o.passed {
+			return "got " + redacted
+		}
+
+		return ""
+	}
+
+	var lines []string
+
+	if !o.passed {
+		if o.subValue {
+			lines = append(lines, "got "+value{o.got}.description(p))
+		}
+
+		if o.details != "" {
+			lines = append(lines, o.details)
+		}
+
+		if explainer, ok := o.leaf.(explainer); ok {
+			if details := explainer.explain(p, o.value); details != "" {
+				lines = append(lines, details)
+			}
+		}
+	}
+
+	for _, child := range o.children {
+		lines = append(lines, child.render(p))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,159 @@
+package tst
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BeBetween returns an assertion that passes in case all the values to be tested
+// are within the range between lo and hi.
+// By default, both bounds are included in the range, use bounds argument to change that.
+func BeBetween(lo, hi any, bounds ...Bounds) Assertion {
+	r := Range{Lo: lo, Hi: hi}
+	if len(bounds) != 0 {
+		r.Bounds = bounds[0]
+	}
+
+	return inRange{"BeBetween", []Range{r}}
+}
+
+// BeInRange returns an assertion that passes in case values to be tested using it are within corresponding specified ranges.
+// Number of values to test with this assertion must match the number of the specified ranges.
+func BeInRange(ranges ...Range) Assertion {
+	return inRange{"BeInRange", ranges}
+}
+
+// ---
+
+// Range is a range of values between Lo and Hi, used by [BeInRange].
+type Range struct {
+	Lo     any
+	Hi     any
+	Bounds Bounds
+}
+
+func (r Range) contains(i int, v any) (bool, error) {
+	lo, err := compare(i, reflect.ValueOf(v), reflect.ValueOf(r.Lo))
+	if err != nil {
+		return false, err
+	}
+
+	hi, err := compare(i, reflect.ValueOf(v), reflect.ValueOf(r.Hi))
+	if err != nil {
+		return false, err
+	}
+
+	switch r.Bounds {
+	case Open:
+		return lo > 0 && hi < 0, nil
+	case ClosedOpen:
+		return lo >= 0 && hi < 0, nil
+	case OpenClosed:
+		return lo > 0 && hi <= 0, nil
+	default:
+		return lo >= 0 && hi <= 0, nil
+	}
+}
+
+// String returns a string representation of the range, like `[<int>: 1, <int>: 5)`.
+func (r Range) String() string {
+	return r.format(defaultPrinter)
+}
+
+func (r Range) format(p *printer) string {
+	left, right := "[", "]"
+
+	switch r.Bounds {
+	case Open:
+		left, right = "(", ")"
+	case ClosedOpen:
+		right = ")"
+	case OpenClosed:
+		left = "("
+	}
+
+	return fmt.Sprintf("%s%s, %s%s", left, value{r.Lo}.description(p), value{r.Hi}.description(p), right)
+}
+
+// ---
+
+// Bounds defines which bounds of a [Range] are included in it.
+type Bounds int
+
+const (
+	// Closed range includes both of its bounds, like [lo, hi].
+	Closed Bounds = iota
+	// Open range excludes both of its bounds, like (lo, hi).
+	Open
+	// ClosedOpen range includes its lower bound and excludes its upper bound, like [lo, hi).
+	ClosedOpen
+	// OpenClosed range excludes its lower bound and includes its upper bound, like (lo, hi].
+	OpenClosed
+)
+
+// ---
+
+type inRange struct {
+	matcher
+
+	ranges []Range
+}
+
+func (a inRange) check(actual []any) ([]bool, error) {
+	if err := a.checkArity(len(actual)); err != nil {
+		return nil, err
+	}
+
+	expected := func(i int) Range {
+		if len(a.ranges) == 1 {
+			return a.ranges[0]
+		}
+
+		return a.ranges[i]
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		ok, err := expected(i).contains(i, actual[i])
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = ok
+	}
+
+	return result, nil
+}
+
+func (a inRange) description(p *printer) string {
+	if len(a.ranges) == 1 {
+		return "be in " + a.ranges[0].format(p)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("be in")
+
+	for i, r := range a.ranges {
+		fmt.Fprintf(&sb, "\n%s[#%d] %s", indentSnippet, i+1, r.format(p))
+	}
+
+	return sb.String()
+}
+
+func (a inRange) complexity() int {
+	return 1
+}
+
+func (a inRange) at(i int) Assertion {
+	if len(a.ranges) == 1 {
+		return a
+	}
+
+	return inRange{a.matcher, []Range{a.ranges[i]}}
+}
+
+func (a inRange) checkArity(n int) error {
+	return checkNumberOfValues(n, len(a.ranges))
+}
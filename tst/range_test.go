@@ -0,0 +1,66 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestRange(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"closed-lo", 1, tst.BeBetween(1, 5), ""},
+		{"closed-hi", 5, tst.BeBetween(1, 5), ""},
+		{"closed-below", 0, tst.BeBetween(1, 5), "to be in [<int>: 1, <int>: 5]"},
+		{"open-lo", 1, tst.BeBetween(1, 5, tst.Open), "to be in (<int>: 1, <int>: 5)"},
+		{"open-hi", 5, tst.BeBetween(1, 5, tst.Open), "to be in (<int>: 1, <int>: 5)"},
+		{"open-inside", 3, tst.BeBetween(1, 5, tst.Open), ""},
+		{"closed-open-lo", 1, tst.BeBetween(1, 5, tst.ClosedOpen), ""},
+		{"closed-open-hi", 5, tst.BeBetween(1, 5, tst.ClosedOpen), "to be in [<int>: 1, <int>: 5)"},
+		{"open-closed-lo", 1, tst.BeBetween(1, 5, tst.OpenClosed), "to be in (<int>: 1, <int>: 5]"},
+		{"open-closed-hi", 5, tst.BeBetween(1, 5, tst.OpenClosed), ""},
+		{"mixed-kinds", 1.5, tst.BeBetween(1, 2), ""},
+		{"strings", "b", tst.BeBetween("a", "c"), ""},
+		{"time", time.Unix(5, 0), tst.BeBetween(time.Unix(1, 0), time.Unix(10, 0)), ""},
+	})
+}
+
+func TestRangeDescription(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(5).To(tst.BeBetween(1, 5, tst.ClosedOpen))
+	})
+	if !failed || !strings.Contains(output, "to be in [<int>: 1, <int>: 5)") {
+		t.Fatalf("Expected a single range description, got output: %s", output)
+	}
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(1, 7).To(tst.BeInRange(tst.Range{Lo: 0, Hi: 2}, tst.Range{Lo: 3, Hi: 6, Bounds: tst.Open}))
+	})
+	if strings.Contains(output, "value #1") || !strings.Contains(output, "Expected value #2") || !strings.Contains(output, "to be in (<int>: 3, <int>: 6)") {
+		t.Fatalf("Expected only value #2 to fail with its range, got output: %s", output)
+	}
+
+	output, _ = run(func(t tst.Test) {
+		t.Expect(tst.Secret("b")).WithFormat(tst.MaxStringLength(3)).To(tst.BeBetween(tst.Secret("c"), strings.Repeat("z", 10)))
+	})
+	if strings.Contains(output, `"c"`) || !strings.Contains(output, "<redacted>") || strings.Contains(output, strings.Repeat("z", 10)) {
+		t.Fatalf("Expected bounds rendered by the printer with redaction and truncation, got output: %s", output)
+	}
+}
+
+func TestRangeErrors(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect("x").To(tst.BeBetween(1, 5))
+	})
+	if !failed || !strings.Contains(output, "type") {
+		t.Fatalf("Expected a type error, got output: %s", output)
+	}
+
+	output, failed = run(func(t tst.Test) {
+		t.Expect(1, 2, 3).To(tst.BeInRange(tst.Range{Lo: 0, Hi: 2}, tst.Range{Lo: 0, Hi: 2}))
+	})
+	if !failed || !strings.Contains(output, "number of values to test") {
+		t.Fatalf("Expected an arity error, got output: %s", output)
+	}
+}
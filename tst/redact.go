@@ -0,0 +1,110 @@
+package tst
+
+import (
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RegisterScrubber registers a regular expression matching sensitive data
+// to be redacted from all messages logged by the framework in all tests, including values, differences and line tags.
+// If the regular expression has capture groups, only the text matched by the groups is redacted,
+// otherwise the whole match is redacted.
+// For example, `password=(\S+)` redacts the password but keeps the `password=` prefix.
+func RegisterScrubber(re *regexp.Regexp) {
+	scrubbers.Lock()
+	defer scrubbers.Unlock()
+
+	scrubbers.list = append(scrubbers.list, re)
+}
+
+// WithScrubber returns a test option that makes the test redact text matching the regular expression
+// from all messages it logs in addition to the scrubbers registered using [RegisterScrubber].
+func WithScrubber(re *regexp.Regexp) Option {
+	return func(c *core) {
+		p := *c.printer
+		p.scrubbers = append(slices.Clip(p.scrubbers), re)
+		c.printer = &p
+	}
+}
+
+// ---
+
+// Secret is a string holding sensitive data, like a password or a token,
+// that is never rendered in failure messages.
+// Assertions test it as a regular string.
+//
+// Struct fields of other types can be redacted using the `tst:"redact"` struct tag.
+type Secret string
+
+// String returns a redacted representation of the secret.
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString returns a redacted representation of the secret.
+func (s Secret) GoString() string {
+	return redacted
+}
+
+// TstFormat returns a redacted representation of the secret.
+func (s Secret) TstFormat() string {
+	return redacted
+}
+
+// ---
+
+// scrub redacts text matching the scrubbers.
+func (p *printer) scrub(text string) string {
+	scrubbers.RLock()
+	list := append(slices.Clip(scrubbers.list), p.scrubbers...)
+	scrubbers.RUnlock()
+
+	for _, re := range list {
+		text = scrub(re, text)
+	}
+
+	return text
+}
+
+func scrub(re *regexp.Regexp, text string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllLiteralString(text, redacted)
+	}
+
+	var sb strings.Builder
+
+	last := 0
+
+	for _, match := range re.FindAllStringSubmatchIndex(text, -1) {
+		for i := 2; i < len(match); i += 2 {
+			if match[i] < last {
+				continue
+			}
+
+			sb.WriteString(text[last:match[i]])
+			sb.WriteString(redacted)
+			last = match[i+1]
+		}
+	}
+
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
+
+// redactedField reports whether the struct field has the `tst:"redact"` tag.
+func redactedField(field reflect.StructField) bool {
+	return slices.Contains(strings.Split(field.Tag.Get("tst"), ","), "redact")
+}
+
+// ---
+
+var scrubbers struct {
+	sync.RWMutex
+	list []*regexp.Regexp
+}
+
+const redacted = "<redacted>"
@@ -0,0 +1,104 @@
+package tst_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type credentials struct {
+	User     string
+	Password string `tst:"redact"`
+	Token    tst.Secret
+}
+
+func TestRedaction(t *testing.T) {
+	actual := credentials{"alice", "hunter2", "t0ken-a"}
+	expected := credentials{"bob", "swordfish", "t0ken-b"}
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect(actual).ToEqual(expected)
+	})
+	if !failed {
+		t.Fatalf("Expected ToEqual to fail")
+	}
+
+	for _, secret := range []string{"hunter2", "swordfish", "t0ken"} {
+		if strings.Contains(output, secret) {
+			t.Fatalf("Expected %q to be redacted, got output: %s", secret, output)
+		}
+	}
+
+	for _, expected := range []string{
+		`Password: <redacted>, Token: <redacted>`,
+		".Password:\n        actual   <redacted>\n        expected <redacted>",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected output to contain %q, got output: %s", expected, output)
+		}
+	}
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect(actual.Token).To(tst.HavePrefix("t0ken"))
+	})
+	if failed {
+		t.Fatalf("Expected secret to be tested as a regular string")
+	}
+}
+
+func TestScrubbers(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		tt := tst.New(t, tst.WithScrubber(regexp.MustCompile(`password=(\w+)`)), tst.WithScrubber(regexp.MustCompile(`sk-\w+`)))
+		tt.Expect("url?password=hunter2&key=sk-abc123").To(tst.HaveLen(0))
+	})
+	if !failed {
+		t.Fatalf("Expected expectation to fail")
+	}
+
+	if strings.Contains(output, "hunter2") || strings.Contains(output, "abc123") ||
+		!strings.Contains(output, "url?password=<redacted>&key=<redacted>") {
+		t.Fatalf("Expected sensitive data to be scrubbed, got output: %s", output)
+	}
+}
+
+func TestRedactionPaths(t *testing.T) {
+	type session struct {
+		ID    int
+		token tst.Secret
+	}
+
+	creds := credentials{"alice", "hunter2", "t0ken-a"}
+
+	tests := []struct {
+		name   string
+		actual any
+		assert tst.Assertion
+		output string
+	}{
+		{"field", creds, tst.Field("Password", tst.Equal("swordfish")), "got <redacted>"},
+		{"struct-field", &creds, tst.Struct(tst.Field("Password", tst.HaveLen(3))), "got <redacted>"},
+		{"contain-field", []credentials{creds}, tst.Contain(tst.Field("Password", tst.HavePrefix("x"))), "got <redacted>"},
+		{"regexp-groups", tst.Secret("user=admin;pass=hunter2"), tst.MatchRegexp(`pass=(?P<pass>\w+)`,
+			tst.Group("pass", tst.Equal("swordfish")),
+		), "pass: <redacted>"},
+		{"unexported-secret", session{1, "hunter2"}, tst.Equal(session{2, "swordfish"}), "token: <redacted>"},
+		{"xml", tst.Secret(`<a>hunter2</a>`), tst.MatchXML(`<a>swordfish</a>`), "actual   <redacted>"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assert)
+			})
+			if !failed {
+				t.Fatalf("Expected expectation to fail")
+			}
+
+			if strings.Contains(output, "hunter2") || !strings.Contains(output, tc.output) {
+				t.Fatalf("Expected output to contain %q and no secrets, got output: %s", tc.output, output)
+			}
+		})
+	}
+}
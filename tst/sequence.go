@@ -0,0 +1,543 @@
+package tst
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// BeSorted returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences with elements sorted in non-decreasing order.
+// Elements are compared the same way as in [BeLessThan].
+// Sequences are iterated only until the first pair of elements out of order.
+func BeSorted() Assertion {
+	return ordered{"BeSorted", "be sorted", func(i int, prev, next reflect.Value) (bool, error) {
+		r, err := compare(i, next, prev)
+
+		return r >= 0, err
+	}}
+}
+
+// BeSortedBy returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences with elements sorted according to the less function.
+func BeSortedBy[T any](less func(a, b T) bool) Assertion {
+	return ordered{"BeSortedBy", "be sorted by " + funcName(reflect.ValueOf(less)), func(i int, prev, next reflect.Value) (bool, error) {
+		a, err := typedArgument[T](valueOf(prev), i)
+		if err != nil {
+			return false, err
+		}
+
+		b, err := typedArgument[T](valueOf(next), i)
+		if err != nil {
+			return false, err
+		}
+
+		return !less(b, a), nil
+	}}
+}
+
+// BeStrictlyIncreasing returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences with each element greater than the previous one.
+// Elements are compared the same way as in [BeLessThan].
+func BeStrictlyIncreasing() Assertion {
+	return ordered{"BeStrictlyIncreasing", "be strictly increasing", func(i int, prev, next reflect.Value) (bool, error) {
+		r, err := compare(i, next, prev)
+
+		return r > 0, err
+	}}
+}
+
+// HaveUniqueElements returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences with no equal elements.
+// Elements are compared the same way as in [Equal].
+// Sequences are iterated only until the first duplicate element, so they may be infinite if they are expected to fail.
+func HaveUniqueElements() Assertion {
+	return unique{"HaveUniqueElements", "have unique elements", nil}
+}
+
+// HaveUniqueBy returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences with no elements having equal keys returned by the key function.
+func HaveUniqueBy[T any, K comparable](key func(T) K) Assertion {
+	return unique{"HaveUniqueBy", "have unique elements by " + funcName(reflect.ValueOf(key)), func(i int, v reflect.Value) (any, error) {
+		arg, err := typedArgument[T](valueOf(v), i)
+		if err != nil {
+			return nil, err
+		}
+
+		return key(arg), nil
+	}}
+}
+
+// MatchAllElements returns an assertion that passes in case all the values to be tested
+// are slices, arrays or [iter.Seq] sequences having exactly one element for each identifier in the specified map,
+// no elements with other identifiers, and each element passing the assertion specified for its identifier.
+// Identifiers of elements are returned by the id function.
+// Use [Ignore] to accept any element with the given identifier.
+func MatchAllElements[K comparable, T any](id func(T) K, elements map[K]Assertion) Assertion {
+	ids := make([]any, 0, len(elements))
+	assertions := make(map[any]Assertion, len(elements))
+
+	for key, assertion := range elements {
+		ids = append(ids, key)
+		assertions[key] = assertion
+	}
+
+	slices.SortFunc(ids, func(a, b any) int {
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	return matchAllElements{
+		"MatchAllElements",
+		"match all elements identified by " + funcName(reflect.ValueOf(id)),
+		func(i int, v reflect.Value) (any, error) {
+			arg, err := typedArgument[T](valueOf(v), i)
+			if err != nil {
+				return nil, err
+			}
+
+			return id(arg), nil
+		},
+		ids,
+		assertions,
+	}
+}
+
+// ---
+
+type ordered struct {
+	matcher
+
+	name    string
+	inOrder func(i int, prev, next reflect.Value) (bool, error)
+}
+
+func (a ordered) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		elements, err := elementsOf(i, actual[i])
+		if err != nil {
+			return nil, err
+		}
+
+		pair, err := a.firstUnordered(i, elements)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = pair == nil
+	}
+
+	return result, nil
+}
+
+func (a ordered) description(*printer) string {
+	return a.name
+}
+
+func (a ordered) complexity() int {
+	return 1
+}
+
+func (a ordered) at(int) Assertion {
+	return a
+}
+
+func (a ordered) explain(p *printer, actual any) string {
+	elements, err := elementsOf(0, actual)
+	if err != nil {
+		return ""
+	}
+
+	pair, err := a.firstUnordered(0, elements)
+	if err != nil || pair == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("Elements out of order:\n%s\n%s",
+		indent(1, fmt.Sprintf("[%d] %s", pair[0].index, reflected{pair[0].value}.description(p))),
+		indent(1, fmt.Sprintf("[%d] %s", pair[1].index, reflected{pair[1].value}.description(p))),
+	)
+}
+
+// firstUnordered returns the first pair of adjacent elements that are out of order or nil.
+// It stops iterating the elements once the pair is found.
+func (a ordered) firstUnordered(i int, elements sequence) ([]element, error) {
+	var prev element
+
+	for j, v := range elements.all {
+		if j != 0 {
+			ok, err := a.inOrder(i, prev.value, v)
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return []element{prev, {j, v}}, nil
+			}
+		}
+
+		prev = element{j, v}
+	}
+
+	return nil, nil
+}
+
+// ---
+
+type unique struct {
+	matcher
+
+	name string
+	key  func(i int, v reflect.Value) (any, error)
+}
+
+func (a unique) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		elements, err := elementsOf(i, actual[i])
+		if err != nil {
+			return nil, err
+		}
+
+		duplicates, err := a.firstDuplicate(i, elements, false)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = duplicates == nil
+	}
+
+	return result, nil
+}
+
+func (a unique) description(*printer) string {
+	return a.name
+}
+
+func (a unique) complexity() int {
+	return 1
+}
+
+func (a unique) at(int) Assertion {
+	return a
+}
+
+func (a unique) explain(p *printer, actual any) string {
+	elements, err := elementsOf(0, actual)
+	if err != nil {
+		return ""
+	}
+
+	duplicates, err := a.firstDuplicate(0, elements, elements.finite)
+	if err != nil || duplicates == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Duplicate elements found at positions")
+
+	for _, d := range duplicates {
+		fmt.Fprintf(&sb, " [%d]", d.index)
+	}
+
+	for _, d := range duplicates {
+		sb.WriteRune('\n')
+		sb.WriteString(indent(1, fmt.Sprintf("[%d] %s", d.index, reflected{d.value}.description(p))))
+	}
+
+	return sb.String()
+}
+
+// firstDuplicate returns the first element that is equal to one of the previous elements, preceded by that element, or nil.
+// If all is true, it also returns all other elements equal to them, otherwise it stops iterating once the duplicate is found.
+// Hashable keys are looked up in a map, and other keys are compared one by one like in [Equal].
+func (a unique) firstDuplicate(i int, elements sequence, all bool) ([]element, error) {
+	keyOf := func(v reflect.Value) (any, error) {
+		if a.key != nil {
+			return a.key(i, v)
+		}
+
+		return valueOf(v), nil
+	}
+
+	hashable := func(key any) bool {
+		if a.key == nil {
+			return hashableType(elements.elem)
+		}
+
+		return key == nil || reflect.ValueOf(key).Comparable()
+	}
+
+	same := func(x, y any) bool {
+		if hashable(x) && hashable(y) {
+			return x == y
+		}
+
+		return defaultEquality.equal(x, y)
+	}
+
+	var seen []element
+
+	var keys []any
+
+	positions := map[any]int{}
+
+	var unhashable []int
+
+	// find returns the position in seen of an element with a key equal to the key or -1.
+	find := func(key any) int {
+		if hashable(key) {
+			if k, ok := positions[key]; ok {
+				return k
+			}
+
+			return -1
+		}
+
+		for _, k := range unhashable {
+			if defaultEquality.equal(keys[k], key) {
+				return k
+			}
+		}
+
+		return -1
+	}
+
+	var result []element
+
+	var duplicate any
+
+	for j, v := range elements.all {
+		key, err := keyOf(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			if same(duplicate, key) {
+				result = append(result, element{j, v})
+			}
+
+			continue
+		}
+
+		if k := find(key); k != -1 {
+			result = []element{seen[k], {j, v}}
+			if !all {
+				return result, nil
+			}
+
+			duplicate = key
+
+			continue
+		}
+
+		if hashable(key) {
+			positions[key] = len(seen)
+		} else {
+			unhashable = append(unhashable, len(seen))
+		}
+
+		seen = append(seen, element{j, v})
+		keys = append(keys, key)
+	}
+
+	return result, nil
+}
+
+// ---
+
+type matchAllElements struct {
+	matcher
+
+	name       string
+	id         func(i int, v reflect.Value) (any, error)
+	ids        []any
+	assertions map[any]Assertion
+}
+
+func (a matchAllElements) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		elements, err := elementsOf(i, actual[i])
+		if err != nil {
+			return nil, err
+		}
+
+		problems, err := a.problems(defaultPrinter, i, elements, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = len(problems) == 0
+	}
+
+	return result, nil
+}
+
+func (a matchAllElements) description(p *printer) string {
+	var sb strings.Builder
+	sb.WriteString(a.name)
+
+	for _, id := range a.ids {
+		fmt.Fprintf(&sb, "\nwith element %s that is expected to %s", p.print(id), a.assertions[id].description(p))
+	}
+
+	return sb.String()
+}
+
+func (a matchAllElements) complexity() int {
+	return 1 + len(a.ids)
+}
+
+func (a matchAllElements) at(int) Assertion {
+	return a
+}
+
+func (a matchAllElements) explain(p *printer, actual any) string {
+	elements, err := elementsOf(0, actual)
+	if err != nil {
+		return ""
+	}
+
+	problems, err := a.problems(p, 0, elements, maxDifferences)
+	if err != nil || len(problems) == 0 {
+		return ""
+	}
+
+	return "Element mismatches:\n" + indent(1, strings.Join(problems, "\n"))
+}
+
+// problems returns up to limit descriptions of elements that are unexpected, missing, duplicate or do not pass their assertions.
+// It stops iterating the elements once the limit is reached.
+func (a matchAllElements) problems(p *printer, i int, elements sequence, limit int) ([]string, error) {
+	var problems []string
+
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	seen := map[any]int{}
+
+	for j, element := range elements.all {
+		if len(problems) >= limit {
+			return problems, nil
+		}
+
+		id, err := a.id(i, element)
+		if err != nil {
+			return nil, err
+		}
+
+		assertion, expected := a.assertions[id]
+
+		switch k, duplicate := seen[id]; {
+		case !expected:
+			report("[%d] has unexpected id %s", j, p.print(id))
+
+			continue
+		case duplicate:
+			report("[%d] has the same id %s as [%d]", j, p.print(id), k)
+
+			continue
+		}
+
+		seen[id] = j
+
+		o, err := evaluate(assertion, []any{valueOf(element)})
+		if err != nil {
+			return nil, err
+		}
+
+		if !o[0].passed {
+			report("[%d] with id %s is expected to %s%s", j, p.print(id), assertion.description(p), explanation(p, o[0]))
+		}
+	}
+
+	for _, id := range a.ids {
+		if _, ok := seen[id]; !ok && len(problems) < limit {
+			report("element with id %s is missing", p.print(id))
+		}
+	}
+
+	return problems, nil
+}
+
+// ---
+
+// sequence is a lazily iterated sequence of elements of a slice, an array or an [iter.Seq] sequence.
+type sequence struct {
+	all    iter.Seq2[int, reflect.Value]
+	elem   reflect.Type
+	finite bool
+}
+
+// element is an element of a sequence with its position.
+type element struct {
+	index int
+	value reflect.Value
+}
+
+// elementsOf returns elements of a slice, an array, a pointer to an array or an [iter.Seq] sequence.
+func elementsOf(i int, v any) (sequence, error) {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() == reflect.Pointer && rv.Type().Elem().Kind() == reflect.Array && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return sequence{
+			func(yield func(int, reflect.Value) bool) {
+				for j := range rv.Len() {
+					if !yield(j, rv.Index(j)) {
+						return
+					}
+				}
+			},
+			rv.Type().Elem(),
+			true,
+		}, nil
+	case reflect.Func:
+		if rv.Type().CanSeq() && !rv.IsNil() {
+			return sequence{
+				func(yield func(int, reflect.Value) bool) {
+					j := 0
+					for v := range rv.Seq() {
+						if !yield(j, v) {
+							return
+						}
+
+						j++
+					}
+				},
+				rv.Type().In(0).In(0),
+				false,
+			}, nil
+		}
+	}
+
+	return sequence{}, errUnexpectedValueTypeError{i, typeOf(v), "slice, array or sequence"}
+}
+
+// hashableType reports whether values of type t can be used as map keys
+// and their equality as map keys is the same as in [Equal].
+func hashableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		_, ok := t.MethodByName("Equal")
+
+		return !ok
+	default:
+		return false
+	}
+}
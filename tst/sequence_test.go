@@ -0,0 +1,197 @@
+package tst_test
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestSequenceNilInterfaceElements(t *testing.T) {
+	errs := []error{nil, nil}
+	key := func(err error) string {
+		if err == nil {
+			return "<nil>"
+		}
+
+		return err.Error()
+	}
+
+	tests := []struct {
+		name      string
+		assertion tst.Assertion
+		ok        bool
+	}{
+		{"sorted-by", tst.BeSortedBy(func(a, b error) bool { return key(a) < key(b) }), true},
+		{"unique-by", tst.HaveUniqueBy(key), false},
+		{"match-all-elements", tst.MatchAllElements(key, map[string]tst.Assertion{"<nil>": tst.BeNil()}), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(errs).To(tc.assertion)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected assertion to pass = %v, got output: %s", tc.ok, output)
+			}
+		})
+	}
+}
+
+func TestSequence(t *testing.T) {
+	type item struct {
+		ID   string
+		Size int
+	}
+
+	bySize := func(a, b item) bool { return a.Size < b.Size }
+	id := func(v item) string { return v.ID }
+
+	tests := []struct {
+		name      string
+		actual    any
+		assertion tst.Assertion
+		ok        bool
+	}{
+		{"sorted", []int{1, 2, 2, 3}, tst.BeSorted(), true},
+		{"not-sorted", []int{1, 3, 2}, tst.BeSorted(), false},
+		{"sorted-empty", []int{}, tst.BeSorted(), true},
+		{"sorted-array", [3]string{"a", "b", "c"}, tst.BeSorted(), true},
+		{"sorted-array-pointer", &[2]float64{2, 1}, tst.BeSorted(), false},
+		{"sorted-seq", slices.Values([]int{1, 2, 3}), tst.BeSorted(), true},
+		{"sorted-by", []item{{"a", 1}, {"b", 2}}, tst.BeSortedBy(bySize), true},
+		{"not-sorted-by", []item{{"a", 2}, {"b", 1}}, tst.BeSortedBy(bySize), false},
+		{"strictly-increasing", []int{1, 2, 3}, tst.BeStrictlyIncreasing(), true},
+		{"not-strictly-increasing", []int{1, 2, 2}, tst.BeStrictlyIncreasing(), false},
+		{"unique", []int{1, 2, 3}, tst.HaveUniqueElements(), true},
+		{"not-unique", []int{1, 2, 1}, tst.HaveUniqueElements(), false},
+		{"unique-mixed", []any{1, 1.0, "1"}, tst.HaveUniqueElements(), true},
+		{"not-unique-slices", [][]int{{1}, {2}, {1}}, tst.HaveUniqueElements(), false},
+		{"unique-seq", slices.Values([]string{"a", "b"}), tst.HaveUniqueElements(), true},
+		{"unique-by", []item{{"a", 1}, {"b", 1}}, tst.HaveUniqueBy(id), true},
+		{"not-unique-by", []item{{"a", 1}, {"a", 2}}, tst.HaveUniqueBy(id), false},
+		{"match-all", []item{{"a", 1}, {"b", 2}}, tst.MatchAllElements(id, map[string]tst.Assertion{
+			"a": tst.Ignore(),
+			"b": tst.Equal(item{"b", 2}),
+		}), true},
+		{"match-all-mismatch", []item{{"a", 1}, {"b", 3}}, tst.MatchAllElements(id, map[string]tst.Assertion{
+			"a": tst.Ignore(),
+			"b": tst.Equal(item{"b", 2}),
+		}), false},
+		{"match-all-missing", []item{{"a", 1}}, tst.MatchAllElements(id, map[string]tst.Assertion{
+			"a": tst.Ignore(),
+			"b": tst.Ignore(),
+		}), false},
+		{"match-all-unexpected", []item{{"a", 1}, {"c", 1}}, tst.MatchAllElements(id, map[string]tst.Assertion{
+			"a": tst.Ignore(),
+		}), false},
+		{"match-all-duplicate", []item{{"a", 1}, {"a", 2}}, tst.MatchAllElements(id, map[string]tst.Assertion{
+			"a": tst.Ignore(),
+		}), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected assertion to pass = %v, got output: %s", tc.ok, output)
+			}
+		})
+	}
+}
+
+func TestSequenceExplanation(t *testing.T) {
+	tests := []struct {
+		name      string
+		actual    any
+		assertion tst.Assertion
+		expected  []string
+	}{
+		{"sorted", []int{1, 3, 2}, tst.BeSorted(), []string{"Elements out of order:", "[1] <int>: 3", "[2] <int>: 2"}},
+		{"unique", []string{"a", "b", "a", "c", "a"}, tst.HaveUniqueElements(), []string{
+			"Duplicate elements found at positions [0] [2] [4]",
+		}},
+		{"match-all", []int{1, 2}, tst.MatchAllElements(func(v int) int { return v }, map[int]tst.Assertion{
+			1: tst.Ignore(),
+			3: tst.Ignore(),
+		}), []string{"Element mismatches:", "[1] has unexpected id 2", "element with id 3 is missing"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+			if !failed {
+				t.Fatalf("Expected assertion to fail, got output: %s", output)
+			}
+
+			for _, expected := range tc.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("Expected output to contain %q, got: %s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceInfinite(t *testing.T) {
+	// naturals yields 0, 1, 2, ... and then repeats the numbers from the beginning after 5 numbers.
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i % 5) {
+				return
+			}
+		}
+	}
+
+	tests := []struct {
+		name      string
+		assertion tst.Assertion
+		expected  string
+	}{
+		{"sorted", tst.BeSorted(), "[4] <int>: 4"},
+		{"strictly-increasing", tst.BeStrictlyIncreasing(), "[5] <int>: 0"},
+		{"unique", tst.HaveUniqueElements(), "positions [0] [5]"},
+		{"unique-by", tst.HaveUniqueBy(func(v int) int { return v % 3 }), "positions [0] [3]"},
+		{"match-all", tst.MatchAllElements(func(v int) int { return v }, map[int]tst.Assertion{0: tst.Ignore()}), "[1] has unexpected id 1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(iter.Seq[int](naturals)).To(tc.assertion)
+			})
+			if !failed {
+				t.Fatalf("Expected assertion to fail, got output: %s", output)
+			}
+
+			if !strings.Contains(output, tc.expected) {
+				t.Errorf("Expected output to contain %q, got: %s", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestSequenceUnhashableKeys(t *testing.T) {
+	key := func(x int) any { return []int{x % 2} }
+
+	output, failed := run(func(t tst.Test) {
+		t.Expect([]int{1, 2, 3}).To(tst.HaveUniqueBy(key))
+	})
+	if !failed || !strings.Contains(output, "Duplicate elements found at positions [0] [2]") {
+		t.Fatalf("Expected duplicate keys to be reported, got output: %s", output)
+	}
+
+	_, failed = run(func(t tst.Test) {
+		t.Expect([]int{1, 2}).To(tst.HaveUniqueBy(key))
+	})
+	if failed {
+		t.Fatalf("Expected unique keys to pass")
+	}
+}
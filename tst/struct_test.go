@@ -0,0 +1,111 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type account struct {
+	ID      int
+	Name    string
+	Email   string
+	balance int
+}
+
+func TestStructStrict(t *testing.T) {
+	value := account{1, "alice", "alice@example.com", 10}
+
+	tests := []struct {
+		name      string
+		assertion tst.Assertion
+		output    string
+	}{
+		{"all-covered", tst.StructStrict(
+			tst.Field("ID", tst.Equal(1)),
+			tst.Field("Name", tst.Equal("alice")),
+			tst.Field("Email", tst.HaveSuffix("@example.com")),
+		), ""},
+		{"ignored", tst.MatchAllFields(
+			tst.Field("ID", tst.Equal(1)),
+			tst.Field("Name", tst.Equal("alice")),
+			tst.Field("Email", tst.Ignore()),
+		), ""},
+		{"uncovered", tst.StructStrict(
+			tst.Field("ID", tst.Equal(1)),
+		), "Fields not covered by any assertion:\n    Name\n    Email"},
+		{"mismatch", tst.StructStrict(
+			tst.Field("ID", tst.Equal(2)),
+			tst.Field("Name", tst.Ignore()),
+			tst.Field("Email", tst.Ignore()),
+		), "✗ have field \"ID\" that is expected to equal to\n      <int>: 2\n    got <int>: 1"},
+		{"nested", tst.StructStrict(
+			tst.Not(tst.Field("ID", tst.Equal(2))),
+			tst.And(tst.Field("Name", tst.HavePrefix("a")), tst.Field("Name", tst.HaveSuffix("e"))),
+			tst.Or(tst.Struct(tst.Field("Email", tst.Ignore())), tst.BeZero()),
+		), ""},
+		{"nested-uncovered", tst.StructStrict(
+			tst.Not(tst.Field("ID", tst.Equal(2))),
+			tst.Or(tst.Field("Name", tst.Equal("alice")), tst.BeZero()),
+		), "Fields not covered by any assertion:\n    Email"},
+		{"non-strict", tst.Struct(
+			tst.Field("ID", tst.Equal(1)),
+		), ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(&value).To(tc.assertion)
+			})
+			if failed != (tc.output != "") || !strings.Contains(output, tc.output) {
+				t.Fatalf("Expected output to contain %q, got failed=%v and output: %s", tc.output, failed, output)
+			}
+		})
+	}
+}
+
+func TestMatchAllElements(t *testing.T) {
+	id := func(a account) int { return a.ID }
+	values := []account{{ID: 1, Name: "alice"}, {ID: 2, Name: "bob"}}
+
+	tests := []struct {
+		name     string
+		elements map[int]tst.Assertion
+		actual   []account
+		output   string
+	}{
+		{"all-matched", map[int]tst.Assertion{
+			1: tst.HaveField("Name", tst.Equal("alice")),
+			2: tst.Ignore(),
+		}, values, ""},
+		{"mismatch", map[int]tst.Assertion{
+			1: tst.HaveField("Name", tst.Equal("carol")),
+			2: tst.Ignore(),
+		}, values, "[0] with id 1 is expected to have field \"Name\""},
+		{"unexpected", map[int]tst.Assertion{
+			1: tst.Ignore(),
+		}, values, "[1] has unexpected id 2"},
+		{"missing", map[int]tst.Assertion{
+			1: tst.Ignore(),
+			2: tst.Ignore(),
+			3: tst.Ignore(),
+		}, values, "element with id 3 is missing"},
+		{"duplicate", map[int]tst.Assertion{
+			1: tst.Ignore(),
+			2: tst.Ignore(),
+		}, append(values, account{ID: 1}), "[2] has the same id 1 as [0]"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tst.MatchAllElements(id, tc.elements))
+			})
+			if failed != (tc.output != "") || !strings.Contains(output, tc.output) {
+				t.Fatalf("Expected output to contain %q, got failed=%v and output: %s", tc.output, failed, output)
+			}
+		})
+	}
+}
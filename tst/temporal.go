@@ -0,0 +1,115 @@
+package tst
+
+import (
+	"fmt"
+	"time"
+)
+
+// BeTemporally returns an assertion that passes in case all the values to be tested are [time.Time] values
+// that relate to the specified time according to the operator op.
+// Supported operators are "==", "~", "<", "<=", ">" and ">=".
+// Operator "~" tests that the values are within the specified duration from the expected time,
+// the duration must be non-negative and defaults to 1 millisecond, other operators accept no duration.
+// Operator "==" tests that the values represent the same instant using [time.Time.Equal].
+func BeTemporally(op string, expected time.Time, within ...time.Duration) Assertion {
+	threshold := time.Millisecond
+	if len(within) != 0 {
+		threshold = within[0]
+	}
+
+	switch {
+	case op == "~" && len(within) > 1:
+		return invalid{BeTemporally(op, expected, threshold), errTooManyArgumentsError{op, len(within), 1}}
+	case op != "~" && len(within) != 0:
+		return invalid{BeTemporally(op, expected), errTooManyArgumentsError{op, len(within), 0}}
+	case threshold < 0:
+		return invalid{temporal{"BeTemporally", op, expected, threshold, fmt.Sprintf("be within %v of", threshold), nil}, errNegativeDurationError{threshold}}
+	}
+
+	switch op {
+	case "~":
+		return temporal{"BeTemporally", op, expected, threshold, fmt.Sprintf("be within %v of", threshold), nil}
+	case "==":
+		return temporal{"BeTemporally", op, expected, 0, "be the same time as", func(r int) bool { return r == 0 }}
+	case "<":
+		return temporal{"BeTemporally", op, expected, 0, "be before", lt}
+	case "<=":
+		return temporal{"BeTemporally", op, expected, 0, "be before or the same time as", le}
+	case ">":
+		return temporal{"BeTemporally", op, expected, 0, "be after", gt}
+	case ">=":
+		return temporal{"BeTemporally", op, expected, 0, "be after or the same time as", ge}
+	default:
+		return temporal{"BeTemporally", op, expected, 0, "be temporally " + op, nil}
+	}
+}
+
+// BeBefore returns an assertion that passes in case all the values to be tested
+// are [time.Time] values before the specified time.
+func BeBefore(expected time.Time) Assertion {
+	return temporal{"BeBefore", "<", expected, 0, "be before", lt}
+}
+
+// BeAfter returns an assertion that passes in case all the values to be tested
+// are [time.Time] values after the specified time.
+func BeAfter(expected time.Time) Assertion {
+	return temporal{"BeAfter", ">", expected, 0, "be after", gt}
+}
+
+// ---
+
+type temporal struct {
+	matcher
+
+	op                  string
+	expected            time.Time
+	within              time.Duration
+	expectedDescription string
+	expectedResult      func(int) bool
+}
+
+func (a temporal) check(actual []any) ([]bool, error) {
+	if a.op != "~" && a.expectedResult == nil {
+		return nil, errUnsupportedOperatorError{a.op}
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		var t time.Time
+
+		switch v := actual[i].(type) {
+		case time.Time:
+			t = v
+		case *time.Time:
+			if v == nil {
+				return nil, errUnexpectedNilValueError{i, typeOf(t), false}
+			}
+
+			t = *v
+		default:
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), typeOf(a.expected)}
+		}
+
+		if a.op == "~" {
+			d := t.Sub(a.expected)
+			result[i] = d >= -a.within && d <= a.within
+		} else {
+			result[i] = a.expectedResult(t.Compare(a.expected))
+		}
+	}
+
+	return result, nil
+}
+
+func (a temporal) description(p *printer) string {
+	return a.expectedDescription + "\n" + indent(1, value{a.expected}.description(p))
+}
+
+func (a temporal) complexity() int {
+	return 1
+}
+
+func (a temporal) at(int) Assertion {
+	return a
+}
@@ -0,0 +1,102 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+type version struct {
+	major, minor int
+}
+
+func (v version) Compare(other version) int {
+	if v.major != other.major {
+		return v.major - other.major
+	}
+
+	return v.minor - other.minor
+}
+
+type day int
+
+func (d day) Before(other day) bool { return d < other }
+func (d day) After(other day) bool  { return d > other }
+
+type badCompare struct{}
+
+func (badCompare) Compare(badCompare) string { return "" }
+
+func TestTemporal(t *testing.T) {
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	later := base.Add(time.Second)
+
+	tests := []struct {
+		name      string
+		actual    any
+		assertion tst.Assertion
+		ok        bool
+	}{
+		{"same-instant", base.In(time.FixedZone("X", 3600)), tst.BeTemporally("==", base), true},
+		{"not-same-instant", later, tst.BeTemporally("==", base), false},
+		{"approx-default", base.Add(time.Microsecond), tst.BeTemporally("~", base), true},
+		{"approx-default-exceeded", later, tst.BeTemporally("~", base), false},
+		{"approx-within", base.Add(-time.Second), tst.BeTemporally("~", base, time.Second), true},
+		{"approx-zero", base, tst.BeTemporally("~", base, 0), true},
+		{"before", base, tst.BeBefore(later), true},
+		{"not-before", later, tst.BeBefore(base), false},
+		{"after", later, tst.BeAfter(base), true},
+		{"before-or-same", base, tst.BeTemporally("<=", base), true},
+		{"after-or-same", base, tst.BeTemporally(">=", later), false},
+		{"pointer", &base, tst.BeBefore(later), true},
+		{"less-than-time", base, tst.BeLessThan(later), true},
+		{"greater-than-time", base, tst.BeGreaterThan(later), false},
+		{"compare-method", version{1, 2}, tst.BeLessThan(version{1, 10}), true},
+		{"compare-method-equal", version{1, 2}, tst.BeGreaterOrEqualThan(version{1, 2}), true},
+		{"before-after-methods", day(3), tst.BeGreaterThan(day(2)), true},
+		{"before-after-methods-equal", day(3), tst.BeLessThan(day(3)), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+			if failed == tc.ok {
+				t.Fatalf("Expected assertion to pass = %v, got output: %s", tc.ok, output)
+			}
+		})
+	}
+}
+
+func TestTemporalErrors(t *testing.T) {
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		actual    any
+		assertion tst.Assertion
+		message   string
+	}{
+		{"negative-within", base, tst.BeTemporally("~", base, -time.Second), "non-negative"},
+		{"too-many-durations", base, tst.BeTemporally("~", base, time.Second, time.Minute), "optional arguments"},
+		{"duration-with-ordering", base, tst.BeTemporally("<", base, time.Second), "optional arguments"},
+		{"unsupported-operator", base, tst.BeTemporally("!=", base), "not supported"},
+		{"not-time", "2024-01-02", tst.BeBefore(base), "type"},
+		{"nil-pointer", (*time.Time)(nil), tst.BeBefore(base), "nil"},
+		{"wrong-compare-signature", badCompare{}, tst.BeLessThan(badCompare{}), "type"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, failed := run(func(t tst.Test) {
+				t.Expect(tc.actual).To(tc.assertion)
+			})
+			if !failed || !strings.Contains(output, tc.message) {
+				t.Fatalf("Expected assertion to fail with %q, got output: %s", tc.message, output)
+			}
+		})
+	}
+}
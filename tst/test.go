@@ -2,13 +2,52 @@ package tst
 
 import (
 	"testing"
+	"time"
 )
 
 // New constructs a new Test based on the t.
-func New[T HT[T]](t T) Test {
-	return &test[T]{core{TB: t}}
+// Options customize the behavior of the test and all its sub-tests.
+//
+// The behavior of tests is configured in layers, each one overriding the settings of the previous ones:
+//
+//  1. Package defaults, changed in code by [SetDefaultFormat].
+//  2. Environment variables:
+//     - TST_FULL_PATH enables full file paths in line tags;
+//     - FORCE_COLOR and NO_COLOR enable or disable colors if not empty, NO_COLOR wins if both are set;
+//     - TST_COLOR sets colors to `auto`, `always` or `never`, note that `auto` disables colors under `go test`, see [Colors];
+//     - TST_MAX_DEPTH, TST_MAX_LENGTH and TST_MAX_STRING_LENGTH set the limits of [MaxDepth], [MaxLength] and [MaxStringLength];
+//     - TST_SOFT enables soft mode, see [Soft];
+//     - TST_EVENTS enables failure events, see [Events];
+//     - TST_TRACE enables trace mode, see [Trace].
+//  3. Test flags: `-test.fullpath`, `-tst.color`, `-tst.maxdepth`, `-tst.maxlength`, `-tst.maxstringlength`,
+//     `-tst.soft`, `-tst.events` and `-tst.trace`.
+//     The `-tst.*` flags are read from the command line arguments and not registered in the [flag] package,
+//     so they have to follow the `--` separator, like `go test ./pkg -args -- -tst.color=always -tst.soft`.
+//  4. Options passed to [New], like [FullPath], [Soft], [Events], [Trace], [WithFormat] or [WithScrubber], inherited by sub-tests.
+//  5. Format options passed to [Expectation.WithFormat] for a single expectation.
+//
+// Boolean settings accept any value accepted by [strconv.ParseBool].
+func New[T HT[T]](t T, options ...Option) Test {
+	t.Helper()
+
+	c, err := defaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.TB = t
+	c.start = time.Now()
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return &test[T]{c}
 }
 
+// Option is an option that customizes the behavior of a [Test].
+type Option func(*core)
+
 // Test transparently wraps compatible an object compatible with [testing.TB]
 // and adds additional methods to make expectations in an assertive way.
 type Test interface {
@@ -45,7 +84,7 @@ func (t *test[T]) Run(name string, f func(Test)) bool {
 }
 
 func (t *test[T]) Expect(values ...any) Expectation {
-	return Expectation{&t.core, values, CallerLine(1)}
+	return Expectation{&t.core, values, CallerLine(1), t.printer}
 }
 
 func (t *test[T]) AddLineTags(tags ...LineTag) {
@@ -55,7 +94,9 @@ func (t *test[T]) AddLineTags(tags ...LineTag) {
 func (t *test[T]) fork(tt T) *test[T] {
 	tt.Helper()
 
-	fork := &test[T]{core{tt, t.tags}}
+	fork := &test[T]{t.core}
+	fork.TB = tt
+	fork.start = time.Now()
 	setup(fork)
 
 	return fork
@@ -71,7 +112,12 @@ func (t *test[T]) get() *core {
 
 type core struct {
 	testing.TB
-	tags []LineTag
+	tags    []LineTag
+	printer *printer
+	soft    bool
+	events  bool
+	trace   bool
+	start   time.Time
 }
 
 func (c *core) addLineTags(tags ...LineTag) {
@@ -86,7 +132,7 @@ func setup(t Test) {
 		if t.Failed() {
 			for _, tag := range t.get().tags {
 				t.Helper()
-				t.Log("See", tag)
+				t.Log(t.get().printer.scrub("See " + tag.format(t.get().printer.fullPath)))
 			}
 		}
 	})
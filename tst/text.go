@@ -0,0 +1,239 @@
+package tst
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ContainSubstring returns an assertion that passes in case all the values to be tested
+// are strings containing the specified substring.
+//
+// Values of any string type, byte slices and values implementing [fmt.Stringer] are accepted
+// by this and other string assertions.
+func ContainSubstring(substr string) Assertion {
+	return text{"ContainSubstring", "contain substring", substr, func(s string) bool {
+		return strings.Contains(s, substr)
+	}}
+}
+
+// HavePrefix returns an assertion that passes in case all the values to be tested
+// are strings beginning with the specified prefix.
+func HavePrefix(prefix string) Assertion {
+	return text{"HavePrefix", "have prefix", prefix, func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}}
+}
+
+// HaveSuffix returns an assertion that passes in case all the values to be tested
+// are strings ending with the specified suffix.
+func HaveSuffix(suffix string) Assertion {
+	return text{"HaveSuffix", "have suffix", suffix, func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	}}
+}
+
+// EqualFold returns an assertion that passes in case all the values to be tested
+// are strings equal to the specified string under simple Unicode case-folding.
+func EqualFold(expected string) Assertion {
+	return text{"EqualFold", "equal ignoring case to", expected, func(s string) bool {
+		return strings.EqualFold(s, expected)
+	}}
+}
+
+// BeBlank returns an assertion that passes in case all the values to be tested
+// are strings that are empty or consist of white space characters only.
+func BeBlank() Assertion {
+	return text{"BeBlank", "be blank", nil, func(s string) bool {
+		return strings.TrimFunc(s, unicode.IsSpace) == ""
+	}}
+}
+
+// MatchRegexp returns an assertion that passes in case all the values to be tested
+// are strings matching the specified regular expression.
+// Optional groups add assertions for values of named capture groups in the leftmost match.
+func MatchRegexp(expr string, groups ...CaptureGroup) Assertion {
+	return matchRegexp{"MatchRegexp", expr, groups}
+}
+
+// Group returns a capture group assertion to be used in [MatchRegexp].
+// It passes in case the value of the capture group with the specified name passes the specified assertion.
+func Group(name string, assertion Assertion) CaptureGroup {
+	return CaptureGroup{name, assertion}
+}
+
+// ---
+
+// CaptureGroup is an assertion for a value of a named capture group used by [MatchRegexp].
+type CaptureGroup struct {
+	name      string
+	assertion Assertion
+}
+
+// ---
+
+type text struct {
+	matcher
+
+	what     string
+	expected any
+	test     func(string) bool
+}
+
+func (a text) check(actual []any) ([]bool, error) {
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		s, ok := stringOf(actual[i])
+		if !ok {
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "string"}
+		}
+
+		result[i] = a.test(s)
+	}
+
+	return result, nil
+}
+
+func (a text) description(p *printer) string {
+	if a.expected == nil {
+		return a.what
+	}
+
+	return a.what + "\n" + indent(1, value{a.expected}.description(p))
+}
+
+func (a text) complexity() int {
+	return 1
+}
+
+func (a text) at(int) Assertion {
+	return a
+}
+
+// ---
+
+type matchRegexp struct {
+	matcher
+
+	expr   string
+	groups []CaptureGroup
+}
+
+func (a matchRegexp) check(actual []any) ([]bool, error) {
+	re, err := regexp.Compile(a.expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", a.expr, err)
+	}
+
+	for _, group := range a.groups {
+		if re.SubexpIndex(group.name) == -1 {
+			return nil, fmt.Errorf("regular expression %q has no capture group named %q", a.expr, group.name)
+		}
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		s, ok := stringOf(actual[i])
+		if !ok {
+			return nil, errUnexpectedValueTypeError{i, typeOf(actual[i]), "string"}
+		}
+
+		match := re.FindStringSubmatch(s)
+		if match == nil {
+			continue
+		}
+
+		result[i] = true
+
+		for _, group := range a.groups {
+			ok, err := group.assertion.check([]any{match[re.SubexpIndex(group.name)]})
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok[0] {
+				result[i] = false
+
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (a matchRegexp) description(p *printer) string {
+	var sb strings.Builder
+	sb.WriteString("match regular expression\n")
+	sb.WriteString(indent(1, value{a.expr}.description(p)))
+
+	for _, group := range a.groups {
+		fmt.Fprintf(&sb, "\nwith group %q that is expected to %s", group.name, group.assertion.description(p))
+	}
+
+	return sb.String()
+}
+
+func (a matchRegexp) explain(p *printer, actual any) string {
+	re, err := regexp.Compile(a.expr)
+	if err != nil || len(a.groups) == 0 {
+		return ""
+	}
+
+	s, _ := stringOf(actual)
+
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return ""
+	}
+
+	_, secret := actual.(Secret)
+
+	var sb strings.Builder
+	sb.WriteString("Captured groups:")
+
+	for _, group := range a.groups {
+		text := redacted
+		if !secret {
+			text = value{match[re.SubexpIndex(group.name)]}.description(p)
+		}
+
+		fmt.Fprintf(&sb, "\n%s%s: %s", indentSnippet, group.name, text)
+	}
+
+	return sb.String()
+}
+
+func (a matchRegexp) complexity() int {
+	return 1 + len(a.groups)
+}
+
+func (a matchRegexp) at(int) Assertion {
+	return a
+}
+
+// ---
+
+// stringOf returns a string representation of v if it is a string, a byte slice or a [fmt.Stringer].
+func stringOf(v any) (string, bool) {
+	rv := reflect.ValueOf(v)
+
+	switch {
+	case !rv.IsValid(), rv.Kind() == reflect.Pointer && rv.IsNil():
+		return "", false
+	case rv.Kind() == reflect.String:
+		return rv.String(), true
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return string(rv.Bytes()), true
+	}
+
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), true
+	}
+
+	return "", false
+}
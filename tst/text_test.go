@@ -0,0 +1,72 @@
+package tst_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestText(t *testing.T) {
+	type name string
+
+	addr := netip.MustParseAddr("192.168.0.1")
+
+	testAssertions(t, []assertionCase{
+		{"contain", "hello world", tst.ContainSubstring("o w"), ""},
+		{"not-contain", "hello world", tst.ContainSubstring("ow"), "to contain substring\n    <string>: [2] \"ow\""},
+		{"contain-bytes", []byte("hello"), tst.ContainSubstring("ell"), ""},
+		{"contain-stringer", addr, tst.ContainSubstring("168"), ""},
+		{"contain-named", name("alice"), tst.ContainSubstring("lic"), ""},
+		{"prefix", "hello", tst.HavePrefix("he"), ""},
+		{"not-prefix", "hello", tst.HavePrefix("lo"), "to have prefix\n    <string>: [2] \"lo\""},
+		{"prefix-bytes", []byte("hello"), tst.HavePrefix("hel"), ""},
+		{"prefix-stringer", addr, tst.HavePrefix("192."), ""},
+		{"suffix", "hello", tst.HaveSuffix("lo"), ""},
+		{"not-suffix", name("hello"), tst.HaveSuffix("he"), "to have suffix\n    <string>: [2] \"he\""},
+		{"suffix-stringer", addr, tst.HaveSuffix(".1"), ""},
+		{"fold", "Straße", tst.EqualFold("STRAßE"), ""},
+		{"fold-bytes", []byte("Go"), tst.EqualFold("gO"), ""},
+		{"fold-named", name("Alice"), tst.EqualFold("bob"), "to equal ignoring case to\n    <string>: [3] \"bob\""},
+		{"blank-empty", "", tst.BeBlank(), ""},
+		{"blank-spaces", " \t\n ", tst.BeBlank(), ""},
+		{"blank-bytes", []byte(" "), tst.BeBlank(), ""},
+		{"not-blank", name(" x "), tst.BeBlank(), "to be blank"},
+		{"regexp", "v1.2.3", tst.MatchRegexp(`^v\d+\.\d+\.\d+$`), ""},
+		{"not-regexp", "1.2", tst.MatchRegexp(`^v\d+`), "to match regular expression\n    <string>: [5] \"^v\\\\d+\""},
+		{"regexp-bytes", []byte("abc"), tst.MatchRegexp(`b`), ""},
+		{"regexp-stringer", addr, tst.MatchRegexp(`^192\.`), ""},
+		{"regexp-groups", "v1.22", tst.MatchRegexp(`v(?P<major>\d+)\.(?P<minor>\d+)`,
+			tst.Group("major", tst.Equal("1")),
+			tst.Group("minor", tst.HaveLen(2)),
+		), ""},
+		{"regexp-group-fails", "v2.0", tst.MatchRegexp(`v(?P<major>\d+)`,
+			tst.Group("major", tst.Equal("1")),
+		), "Captured groups:\n    major: <string>: [1] \"2\""},
+	})
+}
+
+func TestTextGroupExplanation(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect("id=42").To(tst.MatchRegexp(`id=(?P<id>\d+)`, tst.Group("id", tst.Equal("43"))))
+	})
+	if !failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", output)
+	}
+
+	for _, expected := range []string{`with group "id" that is expected to equal`, "Captured groups:", `id: <string>: [2] "42"`} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestTextErrors(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"not-string", 42, tst.ContainSubstring("4"), "string"},
+		{"nil", nil, tst.HavePrefix(""), "string"},
+		{"invalid-regexp", "a", tst.MatchRegexp(`(`), "invalid regular expression"},
+		{"unknown-group", "a", tst.MatchRegexp(`a`, tst.Group("x", tst.Equal("a"))), `no capture group named "x"`},
+	})
+}
@@ -0,0 +1,51 @@
+package tst
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Trace returns a test option that makes each expectation of the test tested by [Expectation.To]
+// log its line tag, values and assertions along with the time elapsed since the test started, if enabled,
+// no matter whether it passed or failed.
+// It can also be enabled by the TST_TRACE environment variable or the `-tst.trace` test flag, see [New].
+func Trace(enabled bool) Option {
+	return func(c *core) {
+		c.trace = enabled
+	}
+}
+
+// ---
+
+// traceTo logs the evaluated expectation if trace mode is enabled.
+func (e Expectation) traceTo(assertion func(int) Assertion, failed bool) {
+	e.t.Helper()
+
+	if !e.t.trace {
+		return
+	}
+
+	status := "passed"
+	if failed {
+		status = "failed"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Trace %s at %s after %s",
+		status,
+		e.tag.format(e.printer.fullPath),
+		time.Since(e.t.start).Round(time.Microsecond),
+	)
+
+	for i := range e.actual {
+		what := ""
+		if len(e.actual) != 1 {
+			what = fmt.Sprintf("value #%d", i+1)
+		}
+
+		sb.WriteString(msg(e.printer, what, value{e.actual[i]}, assertion(i)))
+	}
+
+	e.log(sb.String())
+}
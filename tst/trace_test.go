@@ -0,0 +1,42 @@
+package tst_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestTrace(t *testing.T) {
+	m := &mockT{}
+
+	tt := tst.New(m, tst.Trace(true), tst.WithFormat(tst.Colors(false)))
+	tt.Expect(42).ToEqual(42)
+	tt.Expect(1, 2).To(tst.BeNumerically(">", 0))
+
+	if m.failed {
+		t.Fatalf("Expected the test to pass, got output: %s", m.output())
+	}
+
+	if len(m.logs) != 2 {
+		t.Fatalf("Expected 2 trace messages, got output: %s", m.output())
+	}
+
+	header := regexp.MustCompile(`^Trace passed at \S*tst/trace_test.go:15 after \d\S*s\n`)
+	if !header.MatchString(m.logs[0]) || !strings.HasSuffix(m.logs[0], "\nExpected \n    <int>: 42\nto equal to\n    <int>: 42") {
+		t.Fatalf("Unexpected trace message: %s", m.logs[0])
+	}
+
+	if !strings.Contains(m.logs[1], "Expected value #1") || !strings.Contains(m.logs[1], "Expected value #2") {
+		t.Fatalf("Expected trace message to contain both values, got: %s", m.logs[1])
+	}
+
+	output, _ := run(func(t tst.Test) {
+		t.Expect(42).ToEqual(42)
+	})
+
+	if output != "" {
+		t.Fatalf("Expected no trace messages by default, got output: %s", output)
+	}
+}
@@ -0,0 +1,295 @@
+package tst
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MatchXML returns an assertion that passes in case all the values to be tested are XML documents
+// semantically equal to the specified XML document.
+// Attribute order, namespace prefixes, comments, processing instructions
+// and leading or trailing white space in text content are ignored.
+// Elements and attributes are compared by their local names and namespace URIs.
+//
+// Both the values to test and the expected document can be strings, byte slices
+// or values implementing [fmt.Stringer].
+// Values of differences are redacted if either document is a [Secret].
+func MatchXML(expected any) Assertion {
+	return matchXML{"MatchXML", expected}
+}
+
+// ---
+
+type matchXML struct {
+	matcher
+
+	expected any
+}
+
+func (a matchXML) check(actual []any) ([]bool, error) {
+	expected, err := a.parseExpected()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(actual))
+
+	for i := range actual {
+		actual, err := parseXML(actual[i])
+		if err != nil {
+			return nil, fmt.Errorf("value to test #%d: %w", i+1, err)
+		}
+
+		d := xmlDiffer{limit: 1}
+		d.walk(actual, expected, "/"+expected.name.Local)
+		result[i] = len(d.differences) == 0
+	}
+
+	return result, nil
+}
+
+func (a matchXML) description(p *printer) string {
+	return "match XML\n" + indent(1, value{a.expected}.description(p))
+}
+
+func (a matchXML) complexity() int {
+	return 1
+}
+
+func (a matchXML) at(int) Assertion {
+	return a
+}
+
+func (a matchXML) explain(p *printer, actual any) string {
+	expected, err := a.parseExpected()
+	if err != nil {
+		return ""
+	}
+
+	doc, err := parseXML(actual)
+	if err != nil {
+		return ""
+	}
+
+	d := xmlDiffer{limit: maxDifferences + 1}
+	d.walk(doc, expected, "/"+expected.name.Local)
+
+	if len(d.differences) == 0 {
+		return ""
+	}
+
+	_, actualSecret := actual.(Secret)
+	_, expectedSecret := a.expected.(Secret)
+
+	if actualSecret || expectedSecret {
+		for i := range d.differences {
+			d.differences[i].actual = redacted
+			d.differences[i].expected = redacted
+		}
+	}
+
+	return d.differences.description(p)
+}
+
+func (a matchXML) parseExpected() (*xmlNode, error) {
+	expected, err := parseXML(a.expected)
+	if err != nil {
+		return nil, fmt.Errorf("value in assertion: %w", err)
+	}
+
+	return expected, nil
+}
+
+// ---
+
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+func (n *xmlNode) attr(name xml.Name) (string, bool) {
+	for _, attr := range n.attrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// childPath returns an XPath-like path of the child element with index i.
+func (n *xmlNode) childPath(path string, i int) string {
+	child := n.children[i]
+	position, count := 0, 0
+
+	for j, sibling := range n.children {
+		if sibling.name == child.name {
+			count++
+
+			if j <= i {
+				position++
+			}
+		}
+	}
+
+	path += "/" + child.name.Local
+	if count > 1 {
+		path += "[" + strconv.Itoa(position) + "]"
+	}
+
+	return path
+}
+
+// ---
+
+type xmlDiffer struct {
+	limit       int
+	differences textDifferences
+}
+
+func (d *xmlDiffer) report(path, actual, expected string) {
+	if len(d.differences) < d.limit {
+		d.differences = append(d.differences, textDifference{path, actual, expected})
+	}
+}
+
+func (d *xmlDiffer) walk(actual, expected *xmlNode, path string) {
+	if len(d.differences) >= d.limit {
+		return
+	}
+
+	if actual.name != expected.name {
+		d.report(path, xmlElementText(actual), xmlElementText(expected))
+
+		return
+	}
+
+	for _, attr := range expected.attrs {
+		value, ok := actual.attr(attr.Name)
+
+		switch {
+		case !ok:
+			d.report(path+"/@"+attr.Name.Local, "<missing>", strconv.Quote(attr.Value))
+		case value != attr.Value:
+			d.report(path+"/@"+attr.Name.Local, strconv.Quote(value), strconv.Quote(attr.Value))
+		}
+	}
+
+	for _, attr := range actual.attrs {
+		if _, ok := expected.attr(attr.Name); !ok {
+			d.report(path+"/@"+attr.Name.Local, strconv.Quote(attr.Value), "<missing>")
+		}
+	}
+
+	if actual.text != expected.text {
+		d.report(path+"/text()", strconv.Quote(actual.text), strconv.Quote(expected.text))
+	}
+
+	n := min(len(actual.children), len(expected.children))
+	for i := range n {
+		d.walk(actual.children[i], expected.children[i], expected.childPath(path, i))
+	}
+
+	for i := n; i < len(actual.children); i++ {
+		d.report(actual.childPath(path, i), xmlElementText(actual.children[i]), "<missing>")
+	}
+
+	for i := n; i < len(expected.children); i++ {
+		d.report(expected.childPath(path, i), "<missing>", xmlElementText(expected.children[i]))
+	}
+}
+
+// ---
+
+func parseXML(v any) (*xmlNode, error) {
+	text, ok := stringOf(v)
+	if !ok {
+		return nil, fmt.Errorf("expected XML document of type string or []byte but got <%s>", typeOf(v))
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(text))
+
+	var root *xmlNode
+	var stack []*xmlNode
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid XML: %w", err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: token.Name, attrs: normalizeXMLAttrs(token.Attr)}
+
+			switch {
+			case len(stack) != 0:
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			case root != nil:
+				return nil, errors.New("invalid XML: multiple root elements")
+			default:
+				root = node
+			}
+
+			stack = append(stack, node)
+
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			node.text = strings.TrimSpace(node.text)
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) != 0 {
+				stack[len(stack)-1].text += string(token)
+			} else if strings.TrimSpace(string(token)) != "" {
+				return nil, errors.New("invalid XML: text outside of the root element")
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("invalid XML: no root element")
+	}
+
+	return root, nil
+}
+
+// normalizeXMLAttrs removes namespace declarations and sorts the remaining attributes by name.
+func normalizeXMLAttrs(attrs []xml.Attr) []xml.Attr {
+	result := make([]xml.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			continue
+		}
+
+		result = append(result, attr)
+	}
+
+	slices.SortFunc(result, func(a, b xml.Attr) int {
+		return cmp.Or(cmp.Compare(a.Name.Space, b.Name.Space), cmp.Compare(a.Name.Local, b.Name.Local))
+	})
+
+	return result
+}
+
+func xmlElementText(node *xmlNode) string {
+	if node.name.Space == "" {
+		return "<" + node.name.Local + ">"
+	}
+
+	return "<{" + node.name.Space + "}" + node.name.Local + ">"
+}
@@ -0,0 +1,57 @@
+package tst_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pamburus/go-tst/tst"
+)
+
+func TestXML(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"equal", `<a x="1"><b>text</b></a>`, tst.MatchXML(`<a x="1"><b>text</b></a>`), ""},
+		{"attribute-order", `<a x="1" y="2"/>`, tst.MatchXML(`<a y="2" x="1"></a>`), ""},
+		{"namespace-prefix", `<p:a xmlns:p="urn:x"><p:b/></p:a>`, tst.MatchXML(`<q:a xmlns:q="urn:x"><q:b/></q:a>`), ""},
+		{"default-namespace", `<a xmlns="urn:x"><b/></a>`, tst.MatchXML(`<p:a xmlns:p="urn:x"><p:b/></p:a>`), ""},
+		{"namespace-mismatch", `<p:a xmlns:p="urn:x"/>`, tst.MatchXML(`<p:a xmlns:p="urn:y"/>`), "/a:\n        actual   <{urn:x}a>\n        expected <{urn:y}a>"},
+		{"white-space", "<?xml version=\"1.0\"?>\n<a>\n  <b> text </b>\n</a>\n", tst.MatchXML(`<a><b>text</b></a>`), ""},
+		{"comments", `<a><!-- note --><b/></a>`, tst.MatchXML(`<a><b/></a>`), ""},
+		{"bytes", []byte(`<a/>`), tst.MatchXML([]byte(`<a></a>`)), ""},
+		{"text-mismatch", `<a>x</a>`, tst.MatchXML(`<a>y</a>`), "/a/text():\n        actual   \"x\"\n        expected \"y\""},
+		{"attribute-mismatch", `<a x="1"/>`, tst.MatchXML(`<a x="2"/>`), "/a/@x:\n        actual   \"1\"\n        expected \"2\""},
+		{"extra-attribute", `<a x="1"/>`, tst.MatchXML(`<a/>`), "/a/@x:\n        actual   \"1\"\n        expected <missing>"},
+		{"element-order", `<a><b/><c/></a>`, tst.MatchXML(`<a><c/><b/></a>`), "/a/c:\n        actual   <b>\n        expected <c>"},
+		{"extra-element", `<a><b/><b/></a>`, tst.MatchXML(`<a><b/></a>`), "/a/b[2]:\n        actual   <b>\n        expected <missing>"},
+	})
+}
+
+func TestXMLDifferences(t *testing.T) {
+	output, failed := run(func(t tst.Test) {
+		t.Expect(`<a id="1"><b>x</b><b>y</b><c/></a>`).To(tst.MatchXML(`<a id="2"><b>x</b><b>z</b><d/><e/></a>`))
+	})
+	if !failed {
+		t.Fatalf("Expected assertion to fail, got output: %s", output)
+	}
+
+	for _, expected := range []string{
+		"/a/@id:\n        actual   \"1\"\n        expected \"2\"",
+		"/a/b[2]/text():\n        actual   \"y\"\n        expected \"z\"",
+		"/a/d:\n        actual   <c>\n        expected <d>",
+		"/a/e:\n        actual   <missing>\n        expected <e>",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got: %s", expected, output)
+		}
+	}
+}
+
+func TestXMLErrors(t *testing.T) {
+	testAssertions(t, []assertionCase{
+		{"unclosed", `<a><b></a>`, tst.MatchXML(`<a/>`), "value to test #1: invalid XML"},
+		{"multiple-roots", `<a/><b/>`, tst.MatchXML(`<a/>`), "multiple root elements"},
+		{"text-outside", `<a/>text`, tst.MatchXML(`<a/>`), "text outside of the root element"},
+		{"empty", ``, tst.MatchXML(`<a/>`), "no root element"},
+		{"invalid-expected", `<a/>`, tst.MatchXML(`<a>`), "value in assertion: invalid XML"},
+		{"unsupported-type", 42, tst.MatchXML(`<a/>`), "expected XML document of type string or []byte"},
+	})
+}